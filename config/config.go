@@ -0,0 +1,311 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+)
+
+// redacted : Shown in place of secrets when a config is logged
+const redacted = "[redacted]"
+
+// Config : Settings for the api server. Each value is taken from, in
+// increasing precedence, the defaults, the TOML config file, environment
+// variables and command line flags. On shutdown readiness fails for
+// ShutdownDelay before the listener closes, then in-flight requests get
+// DrainTimeout to finish. The admin routes are only served when AdminToken
+// is set
+type Config struct {
+	Addr           string        `toml:"addr"`
+	CursorSecret   string        `toml:"cursor_secret"`
+	AdminToken     string        `toml:"admin_token"`
+	IdempotencyTTL time.Duration `toml:"idempotency_ttl"`
+	ShutdownDelay  time.Duration `toml:"shutdown_delay"`
+	DrainTimeout   time.Duration `toml:"drain_timeout"`
+	Postgres       Postgres      `toml:"postgres"`
+}
+
+// Postgres : Database connection settings, laid out like sqlboiler.toml so
+// the same section can be shared. The pool limits map onto the sql.DB
+// setters, where 0 means unlimited, and ConnectTimeout bounds how long
+// startup waits for the database to answer
+type Postgres struct {
+	DBName  string `toml:"dbname"`
+	Host    string `toml:"host"`
+	Port    int    `toml:"port"`
+	User    string `toml:"user"`
+	Pass    string `toml:"pass"`
+	SSLMode string `toml:"sslmode"`
+
+	MaxOpenConns    int           `toml:"max_open_conns"`
+	MaxIdleConns    int           `toml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `toml:"conn_max_lifetime"`
+	ConnectTimeout  time.Duration `toml:"connect_timeout"`
+}
+
+// sslModes : sslmode values accepted by lib/pq
+var sslModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+// Default : The config used when nothing overrides it, which matches the
+// docker compose database
+func Default() Config {
+	return Config{
+		Addr:           ":8080",
+		IdempotencyTTL: 24 * time.Hour,
+		ShutdownDelay:  5 * time.Second,
+		DrainTimeout:   15 * time.Second,
+		Postgres: Postgres{
+			DBName:  "flight",
+			Host:    "localhost",
+			Port:    5432,
+			User:    "boiler",
+			Pass:    "boiler",
+			SSLMode: "disable",
+
+			MaxOpenConns:    20,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnectTimeout:  30 * time.Second,
+		},
+	}
+}
+
+// Load : Builds the config from the defaults, the file named by -config or
+// CONFIG_FILE (config.toml when it exists), the environment and args, then
+// validates it
+func Load(args []string) (Config, error) {
+	cfg := Default()
+
+	flags := flag.NewFlagSet("golang-rest-api", flag.ContinueOnError)
+	file := flags.String("config", "", "path to a TOML config file")
+	addr := flags.String("addr", "", "address to listen on")
+	shutdownDelay := flags.Duration("shutdown-delay", 0, "how long readiness fails before the listener closes")
+	drainTimeout := flags.Duration("drain-timeout", 0, "how long in-flight requests get to finish on shutdown")
+	dbName := flags.String("db-name", "", "postgres database name")
+	dbHost := flags.String("db-host", "", "postgres host")
+	dbPort := flags.Int("db-port", 0, "postgres port")
+	dbUser := flags.String("db-user", "", "postgres user")
+	dbSSLMode := flags.String("db-sslmode", "", "postgres sslmode")
+	dbMaxOpen := flags.Int("db-max-open-conns", 0, "most open connections in the pool, 0 for unlimited")
+	dbMaxIdle := flags.Int("db-max-idle-conns", 0, "most idle connections kept in the pool")
+	dbLifetime := flags.Duration("db-conn-max-lifetime", 0, "longest a connection is reused, 0 for forever")
+	dbTimeout := flags.Duration("db-connect-timeout", 0, "how long startup waits for the database")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	path, required := *file, true
+	if path == "" {
+		path, required = os.Getenv("CONFIG_FILE"), true
+	}
+	if path == "" {
+		path, required = "config.toml", false
+	}
+	if err := cfg.loadFile(path, required); err != nil {
+		return cfg, err
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return cfg, err
+	}
+
+	// Only flags that were passed override the file and environment
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "addr":
+			cfg.Addr = *addr
+		case "shutdown-delay":
+			cfg.ShutdownDelay = *shutdownDelay
+		case "drain-timeout":
+			cfg.DrainTimeout = *drainTimeout
+		case "db-name":
+			cfg.Postgres.DBName = *dbName
+		case "db-host":
+			cfg.Postgres.Host = *dbHost
+		case "db-port":
+			cfg.Postgres.Port = *dbPort
+		case "db-user":
+			cfg.Postgres.User = *dbUser
+		case "db-sslmode":
+			cfg.Postgres.SSLMode = *dbSSLMode
+		case "db-max-open-conns":
+			cfg.Postgres.MaxOpenConns = *dbMaxOpen
+		case "db-max-idle-conns":
+			cfg.Postgres.MaxIdleConns = *dbMaxIdle
+		case "db-conn-max-lifetime":
+			cfg.Postgres.ConnMaxLifetime = *dbLifetime
+		case "db-connect-timeout":
+			cfg.Postgres.ConnectTimeout = *dbTimeout
+		}
+	})
+
+	return cfg, cfg.Validate()
+}
+
+// loadFile : Overlays the TOML file at path, which may only be missing when
+// it wasn't asked for explicitly
+func (cfg *Config) loadFile(path string, required bool) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("config: failed to read %s: %v", path, err)
+	}
+
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("config: failed to parse %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// loadEnv : Overlays the environment. Postgres settings use the standard
+// libpq variable names
+func (cfg *Config) loadEnv() error {
+	strs := map[string]*string{
+		"ADDR":          &cfg.Addr,
+		"CURSOR_SECRET": &cfg.CursorSecret,
+		"ADMIN_TOKEN":   &cfg.AdminToken,
+		"PGDATABASE":    &cfg.Postgres.DBName,
+		"PGHOST":        &cfg.Postgres.Host,
+		"PGUSER":        &cfg.Postgres.User,
+		"PGPASSWORD":    &cfg.Postgres.Pass,
+		"PGSSLMODE":     &cfg.Postgres.SSLMode,
+	}
+	for key, field := range strs {
+		if value, ok := os.LookupEnv(key); ok {
+			*field = value
+		}
+	}
+
+	ints := map[string]*int{
+		"PGPORT":            &cfg.Postgres.Port,
+		"DB_MAX_OPEN_CONNS": &cfg.Postgres.MaxOpenConns,
+		"DB_MAX_IDLE_CONNS": &cfg.Postgres.MaxIdleConns,
+	}
+	for key, field := range ints {
+		if value, ok := os.LookupEnv(key); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("config: %s must be a number, got %q", key, value)
+			}
+			*field = n
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"IDEMPOTENCY_TTL":      &cfg.IdempotencyTTL,
+		"SHUTDOWN_DELAY":       &cfg.ShutdownDelay,
+		"DRAIN_TIMEOUT":        &cfg.DrainTimeout,
+		"DB_CONN_MAX_LIFETIME": &cfg.Postgres.ConnMaxLifetime,
+		"DB_CONNECT_TIMEOUT":   &cfg.Postgres.ConnectTimeout,
+	}
+	for key, field := range durations {
+		if value, ok := os.LookupEnv(key); ok {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("config: %s must be a duration, got %q", key, value)
+			}
+			*field = d
+		}
+	}
+
+	return nil
+}
+
+// Validate : Reports every setting that can't be used to start the server
+func (cfg Config) Validate() error {
+	var errs []string
+	if cfg.Addr == "" {
+		errs = append(errs, "addr is required")
+	}
+	if cfg.IdempotencyTTL <= 0 {
+		errs = append(errs, "idempotency_ttl must be positive")
+	}
+	if cfg.ShutdownDelay < 0 {
+		errs = append(errs, "shutdown_delay can't be negative")
+	}
+	if cfg.DrainTimeout <= 0 {
+		errs = append(errs, "drain_timeout must be positive")
+	}
+	if cfg.Postgres.DBName == "" {
+		errs = append(errs, "postgres.dbname is required")
+	}
+	if cfg.Postgres.Host == "" {
+		errs = append(errs, "postgres.host is required")
+	}
+	if cfg.Postgres.Port < 1 || cfg.Postgres.Port > 65535 {
+		errs = append(errs, "postgres.port must be between 1 and 65535")
+	}
+	if cfg.Postgres.User == "" {
+		errs = append(errs, "postgres.user is required")
+	}
+	if !contains(sslModes, cfg.Postgres.SSLMode) {
+		errs = append(errs, "postgres.sslmode must be one of "+strings.Join(sslModes, ", "))
+	}
+	if cfg.Postgres.MaxOpenConns < 0 || cfg.Postgres.MaxIdleConns < 0 {
+		errs = append(errs, "postgres.max_open_conns and max_idle_conns can't be negative")
+	} else if cfg.Postgres.MaxOpenConns > 0 && cfg.Postgres.MaxIdleConns > cfg.Postgres.MaxOpenConns {
+		errs = append(errs, "postgres.max_idle_conns can't exceed max_open_conns")
+	}
+	if cfg.Postgres.ConnMaxLifetime < 0 {
+		errs = append(errs, "postgres.conn_max_lifetime can't be negative")
+	}
+	if cfg.Postgres.ConnectTimeout <= 0 {
+		errs = append(errs, "postgres.connect_timeout must be positive")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ConnString : The lib/pq connection string for these settings
+func (pg Postgres) ConnString() string {
+	return fmt.Sprintf("dbname=%s host=%s port=%d user=%s password=%s sslmode=%s",
+		quote(pg.DBName), quote(pg.Host), pg.Port, quote(pg.User), quote(pg.Pass), pg.SSLMode)
+}
+
+// Redacted : A copy of the config with secrets masked, safe to log
+func (cfg Config) Redacted() Config {
+	if cfg.CursorSecret != "" {
+		cfg.CursorSecret = redacted
+	}
+	if cfg.AdminToken != "" {
+		cfg.AdminToken = redacted
+	}
+	if cfg.Postgres.Pass != "" {
+		cfg.Postgres.Pass = redacted
+	}
+	return cfg
+}
+
+// String : Renders the redacted config, so logging a config never leaks secrets
+func (cfg Config) String() string {
+	type plain Config
+	return fmt.Sprintf("%+v", plain(cfg.Redacted()))
+}
+
+// quote : Quotes a connection string value, escaping quotes and backslashes
+func quote(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `'`, `\'`, -1)
+	return "'" + value + "'"
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// TestLoadPrecedence : Assert flags override env, which overrides the file and defaults
+func TestLoadPrecedence(t *testing.T) {
+	file, err := ioutil.TempFile("", "config*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	file.WriteString("addr = \":9000\"\nidempotency_ttl = \"1h\"\n\n[postgres]\nhost = \"file-host\"\nuser = \"file-user\"\n")
+	file.Close()
+
+	os.Setenv("PGHOST", "env-host")
+	os.Setenv("PGPORT", "6543")
+	defer os.Unsetenv("PGHOST")
+	defer os.Unsetenv("PGPORT")
+
+	cfg, err := Load([]string{"-config", file.Name(), "-db-port", "7654"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, cfg.Addr, ":9000")
+	assert.Equal(t, cfg.IdempotencyTTL, time.Hour)
+	assert.Equal(t, cfg.Postgres.DBName, "flight")
+	assert.Equal(t, cfg.Postgres.User, "file-user")
+	assert.Equal(t, cfg.Postgres.Host, "env-host")
+	assert.Equal(t, cfg.Postgres.Port, 7654)
+}
+
+// TestLoadMissingFile : Assert an explicitly named config file must exist
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load([]string{"-config", "missing.toml"})
+	assert.Equal(t, err != nil, true)
+
+	_, err = Load(nil)
+	assert.Equal(t, err, nil)
+}
+
+// TestValidate : Assert every invalid setting is reported
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Addr = ""
+	cfg.Postgres.Port = 0
+	cfg.Postgres.SSLMode = "sometimes"
+	cfg.Postgres.MaxIdleConns = cfg.Postgres.MaxOpenConns + 1
+	cfg.Postgres.ConnectTimeout = 0
+
+	err := cfg.Validate()
+	assert.Equal(t, err != nil, true)
+	assert.Equal(t, strings.Contains(err.Error(), "addr is required"), true)
+	assert.Equal(t, strings.Contains(err.Error(), "postgres.port"), true)
+	assert.Equal(t, strings.Contains(err.Error(), "postgres.sslmode"), true)
+	assert.Equal(t, strings.Contains(err.Error(), "max_idle_conns can't exceed"), true)
+	assert.Equal(t, strings.Contains(err.Error(), "postgres.connect_timeout"), true)
+	assert.Equal(t, Default().Validate(), nil)
+}
+
+// TestRedacted : Assert secrets never appear when a config is logged
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.CursorSecret = "hunter2"
+	cfg.AdminToken = "letmein"
+	cfg.Postgres.Pass = "swordfish"
+
+	s := cfg.String()
+	assert.Equal(t, strings.Contains(s, "hunter2"), false)
+	assert.Equal(t, strings.Contains(s, "letmein"), false)
+	assert.Equal(t, strings.Contains(s, "swordfish"), false)
+	assert.Equal(t, strings.Contains(s, redacted), true)
+	assert.Equal(t, cfg.Postgres.Pass, "swordfish")
+}
@@ -3,19 +3,68 @@ package main
 //go:generate sqlboiler postgres
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"github.com/phazyy/golang-rest-api/config"
 	"github.com/phazyy/golang-rest-api/middleware"
+	"github.com/phazyy/golang-rest-api/problem"
 	"github.com/phazyy/golang-rest-api/routes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/inconshreveable/log15.v2"
 )
 
 var log = log15.New()
 
 func main() {
-	r := gin.Default()
-	r.Use(middleware.Logger(log))
-	r.Use(middleware.Database(log))
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Crit("failed to load config", "err", err)
+		os.Exit(1)
+	}
+	log.Info("loaded config", "config", cfg)
+
+	routes.SetCursorSecret(cfg.CursorSecret)
+
+	db, err := middleware.OpenDatabase(cfg.Postgres)
+	if err == nil {
+		err = middleware.WaitForDatabase(log, db, cfg.Postgres.ConnectTimeout)
+	}
+	if err != nil {
+		log.Crit("failed to connect to database", "err", err)
+		os.Exit(1)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, cfg.Postgres.DBName),
+	)
+
+	// Not gin.Default, middleware.Logger replaces gin's own request logging
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(middleware.Metrics(reg))
+	r.Use(middleware.Logger(log, "/healthz", "/readyz", "/metrics"))
+	r.Use(middleware.Database(db))
+	r.Use(middleware.Idempotency(log, cfg.IdempotencyTTL))
+	r.Use(middleware.Errors(log))
+	r.Use(middleware.IDs(log))
+
+	health := new(routes.HealthRoutes)
+
+	r.GET("/healthz", health.Live)
+	r.GET("/readyz", health.Ready)
+	r.GET("/metrics", gin.WrapH(promhttp.HandlerFor(reg, promhttp.HandlerOpts{})))
 
 	v1 := r.Group("/v1")
 	{
@@ -24,16 +73,80 @@ func main() {
 		v1.GET("/pilots", pilot.GetAll)
 		v1.GET("/pilots/:id", pilot.Get)
 		v1.POST("/pilots", pilot.Create)
+		v1.POST("/pilots:batch", pilot.Batch)
 		v1.PUT("/pilots/:id", pilot.Update)
+		v1.PATCH("/pilots/:id", pilot.Patch)
 		v1.DELETE("/pilots/:id", pilot.Delete)
+		v1.POST("/pilots/:id/restore", pilot.Restore)
+		v1.GET("/pilots/:id/jets", pilot.GetJets)
+		v1.POST("/pilots/:id/jets", pilot.CreateJet)
+		v1.GET("/pilots/:id/languages", pilot.GetLanguages)
+		v1.PUT("/pilots/:id/languages/:language_id", pilot.AddLanguage)
+		v1.DELETE("/pilots/:id/languages/:language_id", pilot.RemoveLanguage)
+
+		jet := new(routes.JetRoutes)
+
+		v1.GET("/jets", jet.GetAll)
+		v1.GET("/jets/:id", jet.Get)
+		v1.POST("/jets", jet.Create)
+		v1.POST("/jets:batch", jet.Batch)
+		v1.PUT("/jets/:id", jet.Update)
+		v1.PATCH("/jets/:id", jet.Patch)
+		v1.DELETE("/jets/:id", jet.Delete)
+		v1.POST("/jets/:id/restore", jet.Restore)
+		v1.PUT("/jets/:id/pilot", jet.SetPilot)
+
+		language := new(routes.LanguageRoutes)
+
+		v1.GET("/languages", language.GetAll)
+		v1.GET("/languages/:id", language.Get)
+		v1.POST("/languages", language.Create)
+		v1.PUT("/languages/:id", language.Update)
+		v1.DELETE("/languages/:id", language.Delete)
+	}
+
+	if cfg.AdminToken == "" {
+		log.Info("admin routes disabled, set admin_token to serve them")
+	} else {
+		admin := new(routes.AdminRoutes)
+		group := v1.Group("/admin", middleware.Admin(log, cfg.AdminToken))
+
+		group.DELETE("/pilots/:id", admin.PurgePilot)
+		group.DELETE("/jets/:id", admin.PurgeJet)
 	}
 
 	r.NoRoute(func(c *gin.Context) {
-		c.JSON(404, gin.H{
-			"status":  "404",
-			"message": "Not Found.",
-		})
+		c.Error(problem.New(404, "No route matches "+c.Request.URL.Path))
 	})
 
-	r.Run(":8080")
+	srv := &http.Server{Addr: cfg.Addr, Handler: r}
+	go func() {
+		log.Info("listening", "addr", cfg.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Crit("failed to listen", "addr", cfg.Addr, "err", err)
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+
+	// Fail readiness first so load balancers stop sending requests before the
+	// listener closes, then give in-flight requests time to finish. The
+	// database goes last as draining requests still need it
+	log.Info("shutting down", "signal", sig, "delay", cfg.ShutdownDelay, "drain_timeout", cfg.DrainTimeout)
+	health.Drain()
+	time.Sleep(cfg.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error("failed to drain requests", "err", err)
+	}
+
+	if err := db.Close(); err != nil {
+		log.Error("failed to close database", "err", err)
+	}
+	log.Info("shut down")
 }
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// Admin : Middleware that rejects requests with a 401 unless they carry
+// token as a bearer token in the Authorization header
+func Admin(log log15.Logger, token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		given := strings.TrimPrefix(header, "Bearer ")
+		if given == header || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			log.Warn("gin: admin request not authorized", "path", c.Request.URL.Path)
+			c.Header("WWW-Authenticate", "Bearer")
+			c.Error(problem.New(401, "A valid admin token is required"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,30 +1,69 @@
 package middleware
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq" // import postgres driver
+	"github.com/phazyy/golang-rest-api/config"
 	"gopkg.in/inconshreveable/log15.v2"
 )
 
-// Database : Middleware that opens db connection
-func Database(log log15.Logger) gin.HandlerFunc {
-	db, err := sql.Open("postgres", connString())
-	if err != nil {
-		log.Error("failed to open database", "err", err)
-		return nil
-	}
+// Backoff between startup pings, doubling from the first to the last
+const (
+	minBackoff = 250 * time.Millisecond
+	maxBackoff = 5 * time.Second
+)
 
+// Database : Middleware that hands db to handlers
+func Database(db *sql.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Set("DB", db)
 		c.Next()
 	}
 }
 
-// TODO: Use environment variables here or a config file in the future
-func connString() string {
-	return fmt.Sprintf("dbname=%s host=%s user=%s password=%s sslmode=disable",
-		"flight", "localhost", "boiler", "boiler")
+// OpenDatabase : Opens the postgres pool described by cfg. No connection is
+// made until the pool is first used, see WaitForDatabase
+func OpenDatabase(cfg config.Postgres) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.ConnString())
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	return db, nil
+}
+
+// WaitForDatabase : Pings db until it answers, backing off exponentially
+// between attempts. Gives up with the last error once timeout has passed
+func WaitForDatabase(log log15.Logger, db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	backoff := minBackoff
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			log.Info("db: connected", "attempts", attempt)
+			return nil
+		}
+
+		log.Warn("db: ping failed", "attempt", attempt, "retry_in", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database unreachable after %d attempts in %s: %v", attempt, timeout, err)
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
 }
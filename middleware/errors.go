@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// Errors : Middleware that renders the last error a handler passed to
+// c.Error as application/problem+json
+func Errors(log log15.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		renderProblem(c, log, c.Errors.Last().Err)
+	}
+}
+
+// renderProblem : Writes err as application/problem+json, for middleware that
+// rejects requests before Errors has been reached
+func renderProblem(c *gin.Context, log log15.Logger, err error) {
+	p := problem.From(err)
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.RequestURI()
+	}
+	if p.Status >= 500 {
+		log.Error("gin: request failed", "path", c.Request.URL.Path, "err", err)
+	}
+
+	c.Header("Content-Type", "application/problem+json")
+	c.Render(p.Status, render.JSON{Data: p})
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io/ioutil"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// maxKeyLength : Longest Idempotency-Key accepted
+const maxKeyLength = 255
+
+// Idempotency : Middleware that makes POST requests sent with an
+// Idempotency-Key header safe to retry. The first response for a key is
+// recorded in the idempotency_keys table and replayed for repeats within ttl,
+// while reusing a key with a different query or body is rejected with a 422.
+// Must be registered after Database and before Errors so problem responses
+// are recorded too
+func Idempotency(log log15.Logger, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if c.Request.Method != "POST" || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxKeyLength {
+			log.Error("gin: invalid idempotency key", "key", key)
+			renderProblem(c, log, problem.New(400, "Idempotency-Key must be at most 255 characters"))
+			c.Abort()
+			return
+		}
+
+		db := c.MustGet("DB").(*sql.DB)
+		path := c.Request.URL.Path
+
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			log.Error("gin: failed to read request body", "err", err)
+			renderProblem(c, log, problem.New(400, "Request body couldn't be read"))
+			c.Abort()
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		hash := requestHash(c.Request.Method, c.Request.URL.RawQuery, body)
+
+		// Claim the key, taking over any record that has outlived ttl
+		claim, err := db.Exec(`insert into idempotency_keys (key, path, request_hash) values ($1, $2, $3)
+			on conflict (key, path) do update
+			set request_hash = excluded.request_hash, status = null, content_type = null, body = null, created_at = now()
+			where idempotency_keys.created_at < $4`, key, path, hash, time.Now().Add(-ttl))
+		if err != nil {
+			log.Error("db: failed to claim idempotency key", "key", key, "err", err)
+			renderProblem(c, log, problem.Wrap(err, 500, "Failed to check Idempotency-Key"))
+			c.Abort()
+			return
+		}
+
+		if claimed, _ := claim.RowsAffected(); claimed == 0 {
+			replay(c, db, log, key, path, hash)
+			c.Abort()
+			return
+		}
+
+		// A panicking handler never reaches the recording below, so the claim
+		// is released here rather than left in progress until ttl passes
+		finished := false
+		defer func() {
+			if !finished {
+				release(db, log, key, path)
+			}
+		}()
+
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+		finished = true
+
+		status := c.Writer.Status()
+		if status >= 500 {
+			// Server errors aren't recorded so the request can be retried
+			release(db, log, key, path)
+			return
+		}
+
+		_, err = db.Exec(`update idempotency_keys set status = $3, content_type = $4, body = $5 where key = $1 and path = $2`,
+			key, path, status, c.Writer.Header().Get("Content-Type"), rec.body.Bytes())
+		if err != nil {
+			log.Error("db: failed to record idempotent response", "key", key, "status", status, "err", err)
+		}
+	}
+}
+
+// requestHash : Fingerprint of everything about a request that must match
+// for its recorded response to be replayed
+func requestHash(method, rawQuery string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + "\n" + rawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// release : Deletes the claim on key so the request can be retried
+func release(db *sql.DB, log log15.Logger, key, path string) {
+	if _, err := db.Exec(`delete from idempotency_keys where key = $1 and path = $2`, key, path); err != nil {
+		log.Error("db: failed to release idempotency key", "key", key, "err", err)
+	}
+}
+
+// replay : Writes the response recorded for key, or a problem when it was
+// used with a different request or its first request hasn't finished yet
+func replay(c *gin.Context, db *sql.DB, log log15.Logger, key, path, hash string) {
+	var (
+		recorded    string
+		status      sql.NullInt64
+		contentType sql.NullString
+		body        []byte
+	)
+
+	err := db.QueryRow(`select request_hash, status, content_type, body from idempotency_keys where key = $1 and path = $2`,
+		key, path).Scan(&recorded, &status, &contentType, &body)
+	switch {
+	case err != nil:
+		log.Error("db: failed to get idempotency key", "key", key, "err", err)
+		renderProblem(c, log, problem.Wrap(err, 500, "Failed to check Idempotency-Key"))
+	case recorded != hash:
+		log.Error("gin: idempotency key reused", "key", key, "path", path)
+		renderProblem(c, log, problem.New(422, "Idempotency-Key was already used with a different request"))
+	case !status.Valid:
+		log.Error("gin: idempotency key in progress", "key", key, "path", path)
+		renderProblem(c, log, problem.New(409, "A request with this Idempotency-Key is still in progress"))
+	default:
+		log.Info("gin: replaying idempotent response", "key", key, "path", path, "status", status.Int64)
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(int(status.Int64), contentType.String, body)
+	}
+}
+
+// recorder : Response writer that keeps a copy of the body written through it
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
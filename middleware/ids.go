@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// IDs : Middleware that binds every id path param (:id, :language_id, ...)
+// as an int under the same key, so handlers can read them with c.GetInt.
+// Requests with an id that isn't a positive integer are rejected with a 400
+func IDs(log log15.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, param := range c.Params {
+			if param.Key != "id" && !strings.HasSuffix(param.Key, "_id") {
+				continue
+			}
+
+			id, err := strconv.Atoi(param.Value)
+			if err != nil || id < 1 {
+				log.Error("gin: invalid id param", param.Key, param.Value)
+				c.Error(problem.New(400, param.Key+" must be a positive integer"))
+				c.Abort()
+				return
+			}
+			c.Set(param.Key, id)
+		}
+
+		c.Next()
+	}
+}
@@ -18,12 +18,17 @@ var (
 	reset  = string([]byte{27, 91, 48, 109})
 )
 
-// Logger : Custom middleware for unifing application and gin logs
-func Logger(log log15.Logger) gin.HandlerFunc {
+// Logger : Custom middleware for unifing application and gin logs. Requests
+// to the quiet paths, such as health probes, are only logged when they fail
+func Logger(log log15.Logger, quiet ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Set("logger", log)
 		c.Next()
 
+		if c.Writer.Status() < 500 && isQuiet(quiet, c.Request.URL.Path) {
+			return
+		}
+
 		statusColor := colorForStatus(c.Writer.Status())
 		methodColor := colorForMethod(c.Request.Method)
 
@@ -37,6 +42,15 @@ func Logger(log log15.Logger) gin.HandlerFunc {
 	}
 }
 
+func isQuiet(quiet []string, path string) bool {
+	for _, p := range quiet {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
 func colorForStatus(code int) string {
 	switch {
 	case code >= 200 && code < 300:
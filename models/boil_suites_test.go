@@ -11,56 +11,67 @@ import "testing"
 func TestParent(t *testing.T) {
 	t.Run("Pilots", testPilots)
 	t.Run("Jets", testJets)
+	t.Run("Languages", testLanguages)
 }
 
 func TestDelete(t *testing.T) {
 	t.Run("Pilots", testPilotsDelete)
 	t.Run("Jets", testJetsDelete)
+	t.Run("Languages", testLanguagesDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
 	t.Run("Pilots", testPilotsQueryDeleteAll)
 	t.Run("Jets", testJetsQueryDeleteAll)
+	t.Run("Languages", testLanguagesQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
 	t.Run("Pilots", testPilotsSliceDeleteAll)
 	t.Run("Jets", testJetsSliceDeleteAll)
+	t.Run("Languages", testLanguagesSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
 	t.Run("Pilots", testPilotsExists)
 	t.Run("Jets", testJetsExists)
+	t.Run("Languages", testLanguagesExists)
 }
 
 func TestFind(t *testing.T) {
 	t.Run("Pilots", testPilotsFind)
 	t.Run("Jets", testJetsFind)
+	t.Run("Languages", testLanguagesFind)
 }
 
 func TestBind(t *testing.T) {
 	t.Run("Pilots", testPilotsBind)
 	t.Run("Jets", testJetsBind)
+	t.Run("Languages", testLanguagesBind)
 }
 
 func TestOne(t *testing.T) {
 	t.Run("Pilots", testPilotsOne)
 	t.Run("Jets", testJetsOne)
+	t.Run("Languages", testLanguagesOne)
 }
 
 func TestAll(t *testing.T) {
 	t.Run("Pilots", testPilotsAll)
 	t.Run("Jets", testJetsAll)
+	t.Run("Languages", testLanguagesAll)
 }
 
 func TestCount(t *testing.T) {
 	t.Run("Pilots", testPilotsCount)
 	t.Run("Jets", testJetsCount)
+	t.Run("Languages", testLanguagesCount)
 }
 
 func TestHooks(t *testing.T) {
 	t.Run("Pilots", testPilotsHooks)
 	t.Run("Jets", testJetsHooks)
+	t.Run("Languages", testLanguagesHooks)
 }
 
 func TestInsert(t *testing.T) {
@@ -68,6 +79,8 @@ func TestInsert(t *testing.T) {
 	t.Run("Pilots", testPilotsInsertWhitelist)
 	t.Run("Jets", testJetsInsert)
 	t.Run("Jets", testJetsInsertWhitelist)
+	t.Run("Languages", testLanguagesInsert)
+	t.Run("Languages", testLanguagesInsertWhitelist)
 }
 
 // TestToOne tests cannot be run in parallel
@@ -84,6 +97,8 @@ func TestOneToOne(t *testing.T) {}
 // or deadlocks can occur.
 func TestToMany(t *testing.T) {
 	t.Run("PilotToJets", testPilotToManyJets)
+	t.Run("PilotToLanguages", testPilotToManyLanguages)
+	t.Run("LanguageToPilots", testLanguageToManyPilots)
 }
 
 // TestToOneSet tests cannot be run in parallel
@@ -108,42 +123,56 @@ func TestOneToOneRemove(t *testing.T) {}
 // or deadlocks can occur.
 func TestToManyAdd(t *testing.T) {
 	t.Run("PilotToJets", testPilotToManyAddOpJets)
+	t.Run("PilotToLanguages", testPilotToManyAddOpLanguages)
+	t.Run("LanguageToPilots", testLanguageToManyAddOpPilots)
 }
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManySet(t *testing.T) {}
+func TestToManySet(t *testing.T) {
+	t.Run("PilotToLanguages", testPilotToManySetOpLanguages)
+	t.Run("LanguageToPilots", testLanguageToManySetOpPilots)
+}
 
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyRemove(t *testing.T) {}
+func TestToManyRemove(t *testing.T) {
+	t.Run("PilotToLanguages", testPilotToManyRemoveOpLanguages)
+	t.Run("LanguageToPilots", testLanguageToManyRemoveOpPilots)
+}
 
 func TestReload(t *testing.T) {
 	t.Run("Pilots", testPilotsReload)
 	t.Run("Jets", testJetsReload)
+	t.Run("Languages", testLanguagesReload)
 }
 
 func TestReloadAll(t *testing.T) {
 	t.Run("Pilots", testPilotsReloadAll)
 	t.Run("Jets", testJetsReloadAll)
+	t.Run("Languages", testLanguagesReloadAll)
 }
 
 func TestSelect(t *testing.T) {
 	t.Run("Pilots", testPilotsSelect)
 	t.Run("Jets", testJetsSelect)
+	t.Run("Languages", testLanguagesSelect)
 }
 
 func TestUpdate(t *testing.T) {
 	t.Run("Pilots", testPilotsUpdate)
 	t.Run("Jets", testJetsUpdate)
+	t.Run("Languages", testLanguagesUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
 	t.Run("Pilots", testPilotsSliceUpdateAll)
 	t.Run("Jets", testJetsSliceUpdateAll)
+	t.Run("Languages", testLanguagesSliceUpdateAll)
 }
 
 func TestUpsert(t *testing.T) {
 	t.Run("Pilots", testPilotsUpsert)
 	t.Run("Jets", testJetsUpsert)
+	t.Run("Languages", testLanguagesUpsert)
 }
@@ -0,0 +1,19 @@
+package models
+
+// This file is not generated by sqlboiler, it exposes the generated column
+// lists so callers can whitelist user supplied column names against them.
+
+// PilotColumns returns the columns of the pilots table.
+func PilotColumns() []string {
+	return append([]string(nil), pilotColumns...)
+}
+
+// JetColumns returns the columns of the jets table.
+func JetColumns() []string {
+	return append([]string(nil), jetColumns...)
+}
+
+// LanguageColumns returns the columns of the languages table.
+func LanguageColumns() []string {
+	return append([]string(nil), languageColumns...)
+}
@@ -14,15 +14,17 @@ import (
 	"github.com/vattle/sqlboiler/queries"
 	"github.com/vattle/sqlboiler/queries/qm"
 	"github.com/vattle/sqlboiler/strmangle"
+	"gopkg.in/nullbio/null.v6"
 )
 
 // Jet is an object representing the database table.
 type Jet struct {
-	ID      int    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	PilotID int    `boil:"pilot_id" json:"pilot_id" toml:"pilot_id" yaml:"pilot_id"`
-	Age     int    `boil:"age" json:"age" toml:"age" yaml:"age"`
-	Name    string `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Color   string `boil:"color" json:"color" toml:"color" yaml:"color"`
+	ID        int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PilotID   int       `boil:"pilot_id" json:"pilot_id" toml:"pilot_id" yaml:"pilot_id"`
+	Age       int       `boil:"age" json:"age" toml:"age" yaml:"age"`
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Color     string    `boil:"color" json:"color" toml:"color" yaml:"color"`
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *jetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L jetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -37,8 +39,8 @@ type jetR struct {
 type jetL struct{}
 
 var (
-	jetColumns               = []string{"id", "pilot_id", "age", "name", "color"}
-	jetColumnsWithoutDefault = []string{"pilot_id", "age", "name", "color"}
+	jetColumns               = []string{"id", "pilot_id", "age", "name", "color", "deleted_at"}
+	jetColumnsWithoutDefault = []string{"pilot_id", "age", "name", "color", "deleted_at"}
 	jetColumnsWithDefault    = []string{"id"}
 	jetPrimaryKeyColumns     = []string{"id"}
 )
@@ -635,7 +635,7 @@ func testJetsSelect(t *testing.T) {
 }
 
 var (
-	jetDBTypes = map[string]string{`Age`: `integer`, `Color`: `text`, `ID`: `integer`, `Name`: `text`, `PilotID`: `integer`}
+	jetDBTypes = map[string]string{`Age`: `integer`, `Color`: `text`, `DeletedAt`: `timestamp with time zone`, `ID`: `integer`, `Name`: `text`, `PilotID`: `integer`}
 	_          = bytes.MinRead
 )
 
@@ -14,12 +14,14 @@ import (
 	"github.com/vattle/sqlboiler/queries"
 	"github.com/vattle/sqlboiler/queries/qm"
 	"github.com/vattle/sqlboiler/strmangle"
+	"gopkg.in/nullbio/null.v6"
 )
 
 // Pilot is an object representing the database table.
 type Pilot struct {
-	ID   int    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name string `boil:"name" json:"name" toml:"name" yaml:"name"`
+	ID        int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *pilotR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L pilotL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -27,15 +29,16 @@ type Pilot struct {
 
 // pilotR is where relationships are stored.
 type pilotR struct {
-	Jets JetSlice
+	Jets      JetSlice
+	Languages LanguageSlice
 }
 
 // pilotL is where Load methods for each relationship are stored.
 type pilotL struct{}
 
 var (
-	pilotColumns               = []string{"id", "name"}
-	pilotColumnsWithoutDefault = []string{"name"}
+	pilotColumns               = []string{"id", "name", "deleted_at"}
+	pilotColumnsWithoutDefault = []string{"name", "deleted_at"}
 	pilotColumnsWithDefault    = []string{"id"}
 	pilotPrimaryKeyColumns     = []string{"id"}
 )
@@ -340,6 +343,31 @@ func (o *Pilot) Jets(exec boil.Executor, mods ...qm.QueryMod) jetQuery {
 	return query
 }
 
+// LanguagesG retrieves all the language's languages.
+func (o *Pilot) LanguagesG(mods ...qm.QueryMod) languageQuery {
+	return o.Languages(boil.GetDB(), mods...)
+}
+
+// Languages retrieves all the language's languages with an executor.
+func (o *Pilot) Languages(exec boil.Executor, mods ...qm.QueryMod) languageQuery {
+	queryMods := []qm.QueryMod{
+		qm.Select("\"a\".*"),
+	}
+
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"pilot_languages\" as \"b\" on \"a\".\"id\" = \"b\".\"language_id\""),
+		qm.Where("\"b\".\"pilot_id\"=?", o.ID),
+	)
+
+	query := Languages(exec, queryMods...)
+	queries.SetFrom(query.Query, "\"languages\" as \"a\"")
+	return query
+}
+
 // LoadJets allows an eager lookup of values, cached into the
 // loaded structs of the objects.
 func (pilotL) LoadJets(e boil.Executor, singular bool, maybePilot interface{}) error {
@@ -412,6 +440,93 @@ func (pilotL) LoadJets(e boil.Executor, singular bool, maybePilot interface{}) e
 	return nil
 }
 
+// LoadLanguages allows an eager lookup of values, cached into the
+// loaded structs of the objects.
+func (pilotL) LoadLanguages(e boil.Executor, singular bool, maybePilot interface{}) error {
+	var slice []*Pilot
+	var object *Pilot
+
+	count := 1
+	if singular {
+		object = maybePilot.(*Pilot)
+	} else {
+		slice = *maybePilot.(*PilotSlice)
+		count = len(slice)
+	}
+
+	args := make([]interface{}, count)
+	if singular {
+		if object.R == nil {
+			object.R = &pilotR{}
+		}
+		args[0] = object.ID
+	} else {
+		for i, obj := range slice {
+			if obj.R == nil {
+				obj.R = &pilotR{}
+			}
+			args[i] = obj.ID
+		}
+	}
+
+	query := fmt.Sprintf(
+		"select \"a\".*, \"b\".\"pilot_id\" from \"languages\" as \"a\" inner join \"pilot_languages\" as \"b\" on \"a\".\"id\" = \"b\".\"language_id\" where \"b\".\"pilot_id\" in (%s)",
+		strmangle.Placeholders(dialect.IndexPlaceholders, count, 1, 1),
+	)
+	if boil.DebugMode {
+		fmt.Fprintf(boil.DebugWriter, "%s\n%v\n", query, args)
+	}
+
+	results, err := e.Query(query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load languages")
+	}
+	defer results.Close()
+
+	var resultSlice []*Language
+
+	var localJoinCols []int
+	for results.Next() {
+		one := new(Language)
+		var localJoinCol int
+
+		if err = results.Scan(&one.ID, &one.Language, &localJoinCol); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice languages")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "failed to plebian-bind eager loaded slice languages")
+	}
+
+	if len(languageAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Languages = resultSlice
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if local.ID == localJoinCol {
+				local.R.Languages = append(local.R.Languages, foreign)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddJetsG adds the given related objects to the existing relationships
 // of the pilot, optionally inserting them as new records.
 // Appends related to o.R.Jets.
@@ -496,6 +611,242 @@ func (o *Pilot) AddJets(exec boil.Executor, insert bool, related ...*Jet) error
 	return nil
 }
 
+// AddLanguagesG adds the given related objects to the existing relationships
+// of the pilot, optionally inserting them as new records.
+// Appends related to o.R.Languages.
+// Sets related.R.Pilots appropriately.
+// Uses the global database handle.
+func (o *Pilot) AddLanguagesG(insert bool, related ...*Language) error {
+	return o.AddLanguages(boil.GetDB(), insert, related...)
+}
+
+// AddLanguagesP adds the given related objects to the existing relationships
+// of the pilot, optionally inserting them as new records.
+// Appends related to o.R.Languages.
+// Sets related.R.Pilots appropriately.
+// Panics on error.
+func (o *Pilot) AddLanguagesP(exec boil.Executor, insert bool, related ...*Language) {
+	if err := o.AddLanguages(exec, insert, related...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// AddLanguagesGP adds the given related objects to the existing relationships
+// of the pilot, optionally inserting them as new records.
+// Appends related to o.R.Languages.
+// Sets related.R.Pilots appropriately.
+// Uses the global database handle and panics on error.
+func (o *Pilot) AddLanguagesGP(insert bool, related ...*Language) {
+	if err := o.AddLanguages(boil.GetDB(), insert, related...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// AddLanguages adds the given related objects to the existing relationships
+// of the pilot, optionally inserting them as new records.
+// Appends related to o.R.Languages.
+// Sets related.R.Pilots appropriately.
+func (o *Pilot) AddLanguages(exec boil.Executor, insert bool, related ...*Language) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(exec); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"pilot_languages\" (\"pilot_id\", \"language_id\") values ($1, $2)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, query)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		_, err = exec.Exec(query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &pilotR{
+			Languages: related,
+		}
+	} else {
+		o.R.Languages = append(o.R.Languages, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &languageR{
+				Pilots: PilotSlice{o},
+			}
+		} else {
+			rel.R.Pilots = append(rel.R.Pilots, o)
+		}
+	}
+	return nil
+}
+
+// SetLanguagesG removes all previously related items of the
+// pilot replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Pilots's Languages accordingly.
+// Replaces o.R.Languages with related.
+// Sets related.R.Pilots's Languages accordingly.
+// Uses the global database handle.
+func (o *Pilot) SetLanguagesG(insert bool, related ...*Language) error {
+	return o.SetLanguages(boil.GetDB(), insert, related...)
+}
+
+// SetLanguagesP removes all previously related items of the
+// pilot replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Pilots's Languages accordingly.
+// Replaces o.R.Languages with related.
+// Sets related.R.Pilots's Languages accordingly.
+// Panics on error.
+func (o *Pilot) SetLanguagesP(exec boil.Executor, insert bool, related ...*Language) {
+	if err := o.SetLanguages(exec, insert, related...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// SetLanguagesGP removes all previously related items of the
+// pilot replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Pilots's Languages accordingly.
+// Replaces o.R.Languages with related.
+// Sets related.R.Pilots's Languages accordingly.
+// Uses the global database handle and panics on error.
+func (o *Pilot) SetLanguagesGP(insert bool, related ...*Language) {
+	if err := o.SetLanguages(boil.GetDB(), insert, related...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// SetLanguages removes all previously related items of the
+// pilot replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Pilots's Languages accordingly.
+// Replaces o.R.Languages with related.
+// Sets related.R.Pilots's Languages accordingly.
+func (o *Pilot) SetLanguages(exec boil.Executor, insert bool, related ...*Language) error {
+	query := "delete from \"pilot_languages\" where \"pilot_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeLanguagesFromPilotsSlice(o, related)
+	if o.R != nil {
+		o.R.Languages = nil
+	}
+	return o.AddLanguages(exec, insert, related...)
+}
+
+// RemoveLanguagesP relationships from objects passed in.
+// Removes related items from R.Languages (uses pointer comparison, removal does not keep order)
+// Sets related.R.Pilots.
+// Panics on error.
+func (o *Pilot) RemoveLanguagesP(exec boil.Executor, related ...*Language) {
+	if err := o.RemoveLanguages(exec, related...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// RemoveLanguagesG relationships from objects passed in.
+// Removes related items from R.Languages (uses pointer comparison, removal does not keep order)
+// Sets related.R.Pilots.
+// Uses the global database handle.
+func (o *Pilot) RemoveLanguagesG(related ...*Language) error {
+	return o.RemoveLanguages(boil.GetDB(), related...)
+}
+
+// RemoveLanguagesGP relationships from objects passed in.
+// Removes related items from R.Languages (uses pointer comparison, removal does not keep order)
+// Sets related.R.Pilots.
+// Uses the global database handle and panics on error.
+func (o *Pilot) RemoveLanguagesGP(related ...*Language) {
+	if err := o.RemoveLanguages(boil.GetDB(), related...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// RemoveLanguages relationships from objects passed in.
+// Removes related items from R.Languages (uses pointer comparison, removal does not keep order)
+// Sets related.R.Pilots.
+func (o *Pilot) RemoveLanguages(exec boil.Executor, related ...*Language) error {
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"pilot_languages\" where \"pilot_id\" = $1 and \"language_id\" in (%s)",
+		strmangle.Placeholders(dialect.IndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err = exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeLanguagesFromPilotsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Languages {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Languages)
+			if ln > 1 && i < ln-1 {
+				o.R.Languages[i] = o.R.Languages[ln-1]
+			}
+			o.R.Languages = o.R.Languages[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeLanguagesFromPilotsSlice(o *Pilot, related []*Language) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Pilots {
+			if o.ID != ri.ID {
+				continue
+			}
+
+			ln := len(rel.R.Pilots)
+			if ln > 1 && i < ln-1 {
+				rel.R.Pilots[i] = rel.R.Pilots[ln-1]
+			}
+			rel.R.Pilots = rel.R.Pilots[:ln-1]
+			break
+		}
+	}
+}
+
 // PilotsG retrieves all records.
 func PilotsG(mods ...qm.QueryMod) pilotQuery {
 	return Pilots(boil.GetDB(), mods...)
@@ -531,6 +531,85 @@ func testPilotToManyJets(t *testing.T) {
 	}
 }
 
+func testPilotToManyLanguages(t *testing.T) {
+	var err error
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+
+	var a Pilot
+	var b, c Language
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, pilotDBTypes, true, pilotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Pilot struct: %s", err)
+	}
+
+	if err := a.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	randomize.Struct(seed, &b, languageDBTypes, false, languageColumnsWithDefault...)
+	randomize.Struct(seed, &c, languageDBTypes, false, languageColumnsWithDefault...)
+
+	if err = b.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tx.Exec("insert into \"pilot_languages\" (\"pilot_id\", \"language_id\") values ($1, $2)", a.ID, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tx.Exec("insert into \"pilot_languages\" (\"pilot_id\", \"language_id\") values ($1, $2)", a.ID, c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	language, err := a.Languages(tx).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bFound, cFound := false, false
+	for _, v := range language {
+		if v.ID == b.ID {
+			bFound = true
+		}
+		if v.ID == c.ID {
+			cFound = true
+		}
+	}
+
+	if !bFound {
+		t.Error("expected to find b")
+	}
+	if !cFound {
+		t.Error("expected to find c")
+	}
+
+	slice := PilotSlice{&a}
+	if err = a.L.LoadLanguages(tx, false, &slice); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Languages); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	a.R.Languages = nil
+	if err = a.L.LoadLanguages(tx, true, &a); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Languages); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	if t.Failed() {
+		t.Logf("%#v", language)
+	}
+}
+
 func testPilotToManyAddOpJets(t *testing.T) {
 	var err error
 
@@ -606,6 +685,231 @@ func testPilotToManyAddOpJets(t *testing.T) {
 	}
 }
 
+func testPilotToManyAddOpLanguages(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+
+	var a Pilot
+	var b, c, d, e Language
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, pilotDBTypes, false, strmangle.SetComplement(pilotPrimaryKeyColumns, pilotColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Language{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, languageDBTypes, false, strmangle.SetComplement(languagePrimaryKeyColumns, languageColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	foreignersSplitByInsertion := [][]*Language{
+		{&b, &c},
+		{&d, &e},
+	}
+
+	for i, x := range foreignersSplitByInsertion {
+		err = a.AddLanguages(tx, i != 0, x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first := x[0]
+		second := x[1]
+
+		if first.R.Pilots[0] != &a {
+			t.Error("relationship was not added properly to the slice")
+		}
+		if second.R.Pilots[0] != &a {
+			t.Error("relationship was not added properly to the slice")
+		}
+
+		if a.R.Languages[i*2] != first {
+			t.Error("relationship struct slice not set to correct value")
+		}
+		if a.R.Languages[i*2+1] != second {
+			t.Error("relationship struct slice not set to correct value")
+		}
+
+		count, err := a.Languages(tx).Count()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64((i + 1) * 2); count != want {
+			t.Error("want", want, "got", count)
+		}
+	}
+}
+
+func testPilotToManySetOpLanguages(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+
+	var a Pilot
+	var b, c, d, e Language
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, pilotDBTypes, false, strmangle.SetComplement(pilotPrimaryKeyColumns, pilotColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Language{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, languageDBTypes, false, strmangle.SetComplement(languagePrimaryKeyColumns, languageColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = a.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.SetLanguages(tx, false, &b, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := a.Languages(tx).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	err = a.SetLanguages(tx, true, &d, &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = a.Languages(tx).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	// The following checks cannot be implemented since we have no handle
+	// to these when we call Set(). Leaving them here as wishful thinking
+	// and to let people know there's dragons.
+	//
+	// if len(b.R.Pilots) != 0 {
+	// 	t.Error("relationship was not removed properly from the slice")
+	// }
+	// if len(c.R.Pilots) != 0 {
+	// 	t.Error("relationship was not removed properly from the slice")
+	// }
+	if d.R.Pilots[0] != &a {
+		t.Error("relationship was not added properly to the slice")
+	}
+	if e.R.Pilots[0] != &a {
+		t.Error("relationship was not added properly to the slice")
+	}
+
+	if a.R.Languages[0] != &d {
+		t.Error("relationship struct slice not set to correct value")
+	}
+	if a.R.Languages[1] != &e {
+		t.Error("relationship struct slice not set to correct value")
+	}
+}
+
+func testPilotToManyRemoveOpLanguages(t *testing.T) {
+	var err error
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+
+	var a Pilot
+	var b, c, d, e Language
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, pilotDBTypes, false, strmangle.SetComplement(pilotPrimaryKeyColumns, pilotColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Language{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, languageDBTypes, false, strmangle.SetComplement(languagePrimaryKeyColumns, languageColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.AddLanguages(tx, true, foreigners...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := a.Languages(tx).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Error("count was wrong:", count)
+	}
+
+	err = a.RemoveLanguages(tx, foreigners[:2]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = a.Languages(tx).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	if len(b.R.Pilots) != 0 {
+		t.Error("relationship was not removed properly from the slice")
+	}
+	if len(c.R.Pilots) != 0 {
+		t.Error("relationship was not removed properly from the slice")
+	}
+	if d.R.Pilots[0] != &a {
+		t.Error("relationship was not added properly to the foreign struct")
+	}
+	if e.R.Pilots[0] != &a {
+		t.Error("relationship was not added properly to the foreign struct")
+	}
+
+	if len(a.R.Languages) != 2 {
+		t.Error("should have preserved two relationships")
+	}
+
+	// Removal doesn't do a stable deletion for performance so we have to flip the order
+	if a.R.Languages[1] != &d {
+		t.Error("relationship to d should have been preserved")
+	}
+	if a.R.Languages[0] != &e {
+		t.Error("relationship to e should have been preserved")
+	}
+}
+
 func testPilotsReload(t *testing.T) {
 	t.Parallel()
 
@@ -676,7 +980,7 @@ func testPilotsSelect(t *testing.T) {
 }
 
 var (
-	pilotDBTypes = map[string]string{`ID`: `integer`, `Name`: `text`}
+	pilotDBTypes = map[string]string{`DeletedAt`: `timestamp with time zone`, `ID`: `integer`, `Name`: `text`}
 	_            = bytes.MinRead
 )
 
@@ -0,0 +1,85 @@
+package models
+
+// This file is not generated by sqlboiler, it marks rows deleted rather than
+// removing them so they can be restored. The generated queries return soft
+// deleted rows too, so reads must use the Active variants below unless they
+// want deleted rows on purpose.
+
+import (
+	"time"
+
+	"github.com/vattle/sqlboiler/boil"
+	"github.com/vattle/sqlboiler/queries/qm"
+	"gopkg.in/nullbio/null.v6"
+)
+
+// notDeleted : Hides soft deleted rows. Unqualified, so it also applies to
+// the "a" alias used by relationship queries
+var notDeleted = qm.Where("deleted_at is null")
+
+// ActivePilots retrieves all the pilots that aren't soft deleted.
+func ActivePilots(exec boil.Executor, mods ...qm.QueryMod) pilotQuery {
+	return Pilots(exec, append(mods, notDeleted)...)
+}
+
+// FindActivePilot retrieves a pilot by id, unless it's soft deleted.
+func FindActivePilot(exec boil.Executor, id int, selectCols ...string) (*Pilot, error) {
+	mods := []qm.QueryMod{qm.Where("id = ?", id)}
+	if len(selectCols) > 0 {
+		mods = append(mods, qm.Select(selectCols...))
+	}
+	return ActivePilots(exec, mods...).One()
+}
+
+// ActivePilotExists checks if a pilot exists and isn't soft deleted.
+func ActivePilotExists(exec boil.Executor, id int) (bool, error) {
+	return ActivePilots(exec, qm.Where("id = ?", id)).Exists()
+}
+
+// ActiveJets retrieves the pilot's jets that aren't soft deleted.
+func (o *Pilot) ActiveJets(exec boil.Executor, mods ...qm.QueryMod) jetQuery {
+	return o.Jets(exec, append(mods, notDeleted)...)
+}
+
+// ActiveJets retrieves all the jets that aren't soft deleted.
+func ActiveJets(exec boil.Executor, mods ...qm.QueryMod) jetQuery {
+	return Jets(exec, append(mods, notDeleted)...)
+}
+
+// FindActiveJet retrieves a jet by id, unless it's soft deleted.
+func FindActiveJet(exec boil.Executor, id int, selectCols ...string) (*Jet, error) {
+	mods := []qm.QueryMod{qm.Where("id = ?", id)}
+	if len(selectCols) > 0 {
+		mods = append(mods, qm.Select(selectCols...))
+	}
+	return ActiveJets(exec, mods...).One()
+}
+
+// ActiveJetExists checks if a jet exists and isn't soft deleted.
+func ActiveJetExists(exec boil.Executor, id int) (bool, error) {
+	return ActiveJets(exec, qm.Where("id = ?", id)).Exists()
+}
+
+// SoftDelete marks the pilot deleted at deletedAt.
+func (o *Pilot) SoftDelete(exec boil.Executor, deletedAt time.Time) error {
+	o.DeletedAt = null.TimeFrom(deletedAt)
+	return o.Update(exec, "deleted_at")
+}
+
+// Restore clears the pilot's deleted mark.
+func (o *Pilot) Restore(exec boil.Executor) error {
+	o.DeletedAt = null.Time{}
+	return o.Update(exec, "deleted_at")
+}
+
+// SoftDelete marks the jet deleted at deletedAt.
+func (o *Jet) SoftDelete(exec boil.Executor, deletedAt time.Time) error {
+	o.DeletedAt = null.TimeFrom(deletedAt)
+	return o.Update(exec, "deleted_at")
+}
+
+// Restore clears the jet's deleted mark.
+func (o *Jet) Restore(exec boil.Executor) error {
+	o.DeletedAt = null.Time{}
+	return o.Update(exec, "deleted_at")
+}
@@ -2,7 +2,8 @@ GRANT ALL PRIVILEGES ON DATABASE docker TO docker;
 
 CREATE TABLE pilots (
   id serial NOT NULL,
-  name text NOT NULL
+  name text NOT NULL,
+  deleted_at timestamptz
 );
 
 ALTER TABLE pilots ADD CONSTRAINT pilot_pkey PRIMARY KEY (id);
@@ -12,7 +13,8 @@ CREATE TABLE jets (
   pilot_id serial NOT NULL,
   age serial NOT NULL,
   name text NOT NULL,
-  color text NOT NULL
+  color text NOT NULL,
+  deleted_at timestamptz
 );
 
 ALTER TABLE jets ADD CONSTRAINT jet_pkey PRIMARY KEY (id);
@@ -35,3 +37,16 @@ CREATE TABLE pilot_languages (
 ALTER TABLE pilot_languages ADD CONSTRAINT pilot_language_pkey PRIMARY KEY (pilot_id, language_id);
 ALTER TABLE pilot_languages ADD CONSTRAINT pilot_language_pilots_fkey FOREIGN KEY (pilot_id) REFERENCES pilots(id);
 ALTER TABLE pilot_languages ADD CONSTRAINT pilot_language_languages_fkey FOREIGN KEY (language_id) REFERENCES languages(id);
+
+-- Responses recorded for POST requests sent with an Idempotency-Key
+CREATE TABLE idempotency_keys (
+  key text NOT NULL,
+  path text NOT NULL,
+  request_hash text NOT NULL,
+  status integer,
+  content_type text,
+  body bytea,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_key_pkey PRIMARY KEY (key, path);
@@ -0,0 +1,4 @@
+-- Adds the soft delete columns to databases created before init.sql had them.
+-- Fresh databases get them from init.sql, so this is safe to run on either.
+ALTER TABLE pilots ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE jets ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
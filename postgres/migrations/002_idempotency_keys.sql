@@ -0,0 +1,12 @@
+-- Adds the table the Idempotency middleware records responses in, for
+-- databases created before init.sql had it. Safe to run on either.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  key text NOT NULL,
+  path text NOT NULL,
+  request_hash text NOT NULL,
+  status integer,
+  content_type text,
+  body bytea,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT idempotency_key_pkey PRIMARY KEY (key, path)
+);
@@ -0,0 +1,47 @@
+package problem
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// keyDetail pulls the column list out of a postgres error detail such as
+// Key (pilot_id)=(5) is not present in table "pilots".
+var keyDetail = regexp.MustCompile(`^Key \(([^)]+)\)`)
+
+// fromPostgres : Maps constraint violations and malformed values to a 409 or
+// 422 naming the constraint and field, or nil for any other postgres error
+func fromPostgres(err *pq.Error) *Problem {
+	field := err.Column
+	if field == "" {
+		if match := keyDetail.FindStringSubmatch(err.Detail); match != nil {
+			field = match[1]
+		}
+	}
+
+	var p *Problem
+	switch err.Code {
+	case "23503": // foreign_key_violation
+		if strings.Contains(err.Detail, "still referenced") {
+			p = New(409, fmt.Sprintf("Still referenced by %s (%s)", err.Table, err.Constraint))
+		} else {
+			p = New(422, fmt.Sprintf("Referenced row doesn't exist (%s)", err.Constraint))
+		}
+	case "23505": // unique_violation
+		p = New(409, fmt.Sprintf("Already exists (%s)", err.Constraint))
+	case "23502": // not_null_violation
+		p = New(422, fmt.Sprintf("Missing required value for %s", err.Column))
+	case "22P02": // invalid_text_representation
+		p = New(422, "Value has an invalid format")
+	default:
+		return nil
+	}
+
+	if field != "" {
+		p.Errors = []FieldError{{Field: field, Message: err.Message}}
+	}
+	return p
+}
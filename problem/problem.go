@@ -0,0 +1,127 @@
+package problem
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Problem : An RFC 7807 problem detail, rendered as application/problem+json
+// by the middleware.Errors handler
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+
+	// Extensions are extra members rendered alongside the standard ones
+	Extensions map[string]interface{} `json:"-"`
+
+	cause error
+}
+
+// FieldError : A single invalid field of a request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// New : Creates a problem for status with a human readable detail
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Wrap : As New, but From will prefer the status of err when it's a database
+// error it recognises
+func Wrap(err error, status int, detail string) *Problem {
+	p := New(status, detail)
+	p.cause = err
+	return p
+}
+
+// Invalid : A 422 listing each field that failed validation
+func Invalid(errs []FieldError) *Problem {
+	p := New(422, "Request failed validation")
+	p.Errors = errs
+	return p
+}
+
+// With : Adds an extension member to the problem
+func (p *Problem) With(key string, value interface{}) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]interface{})
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+// MarshalJSON : Flattens Extensions into the problem object
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type plain Problem
+	data, err := json.Marshal((*plain)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]interface{}, len(p.Extensions))
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(members)
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	return p.Title
+}
+
+// From : Converts any error handed to gin into a problem. Unrecognised errors
+// become a 500 without detail so internals aren't leaked to clients
+func From(err error) *Problem {
+	if p, ok := err.(*Problem); ok {
+		if p.cause != nil {
+			if db := fromDB(p.cause); db != nil {
+				if db.Detail == "" {
+					db.Detail = p.Detail
+				}
+				return db
+			}
+		}
+		return p
+	}
+
+	if db := fromDB(err); db != nil {
+		return db
+	}
+	return New(500, "")
+}
+
+// fromDB : Maps sqlboiler and postgres errors to a problem, or nil
+func fromDB(err error) *Problem {
+	cause := errors.Cause(err)
+	if cause == sql.ErrNoRows {
+		return New(404, "")
+	}
+
+	if pqErr, ok := cause.(*pq.Error); ok {
+		return fromPostgres(pqErr)
+	}
+
+	return nil
+}
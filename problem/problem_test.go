@@ -0,0 +1,57 @@
+package problem
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/magiconair/properties/assert"
+)
+
+// TestFromPostgres : Assert postgres error codes map to 409/422 naming the field
+func TestFromPostgres(t *testing.T) {
+	tests := []struct {
+		err    *pq.Error
+		status int
+		field  string
+	}{
+		{&pq.Error{Code: "23503", Constraint: "jet_pilots_fkey", Detail: `Key (pilot_id)=(5) is not present in table "pilots".`}, 422, "pilot_id"},
+		{&pq.Error{Code: "23503", Constraint: "jet_pilots_fkey", Table: "jets", Detail: `Key (id)=(1) is still referenced from table "jets".`}, 409, "id"},
+		{&pq.Error{Code: "23505", Constraint: "jet_pkey", Detail: `Key (id)=(1) already exists.`}, 409, "id"},
+		{&pq.Error{Code: "23502", Column: "name"}, 422, "name"},
+		{&pq.Error{Code: "22P02"}, 422, ""},
+	}
+
+	for _, test := range tests {
+		p := From(Wrap(test.err, 500, "Failed to insert Jet"))
+		assert.Equal(t, p.Status, test.status)
+
+		field := ""
+		if len(p.Errors) > 0 {
+			field = p.Errors[0].Field
+		}
+		assert.Equal(t, field, test.field)
+	}
+}
+
+// TestFromUnknown : Assert unrecognised errors become a 500 without detail
+func TestFromUnknown(t *testing.T) {
+	assert.Equal(t, From(errors.New("boom")).Status, 500)
+	assert.Equal(t, From(errors.New("boom")).Detail, "")
+	assert.Equal(t, From(&pq.Error{Code: "40001"}).Status, 500)
+	assert.Equal(t, From(sql.ErrNoRows).Status, 404)
+	assert.Equal(t, From(Wrap(errors.New("boom"), 500, "Failed")).Detail, "Failed")
+}
+
+// TestExtensions : Assert extension members render alongside the standard ones
+func TestExtensions(t *testing.T) {
+	data, _ := json.Marshal(New(409, "Pilot still owns jets").With("jet_ids", []int{1, 2}))
+
+	var resp map[string]interface{}
+	json.Unmarshal(data, &resp)
+
+	assert.Equal(t, resp["status"], float64(409))
+	assert.Equal(t, resp["jet_ids"], []interface{}{float64(1), float64(2)})
+}
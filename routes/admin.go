@@ -0,0 +1,111 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/models"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/vattle/sqlboiler/queries/qm"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// AdminRoutes : Reach soft deleted rows on purpose, so unlike the other
+// routes they read through the generated queries rather than the Active ones
+type AdminRoutes struct{}
+
+// PurgePilot : Permanently deletes the soft deleted pilot matching the passed
+// id along with their jets and language links. The pilot is locked so a
+// concurrent Restore can't bring them back half purged
+func (route AdminRoutes) PurgePilot(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to purge Pilot"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	pilot, err := models.Pilots(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	if !pilot.DeletedAt.Valid {
+		log.Error("db: purging pilot that isn't deleted", "id", id)
+		c.Error(problem.New(409, "Pilot must be deleted before it's purged"))
+		c.Abort()
+		return
+	}
+
+	err = models.Jets(tx, qm.Where("pilot_id = ?", id)).DeleteAll()
+	if err == nil {
+		err = pilot.SetLanguages(tx, false)
+	}
+	if err == nil {
+		err = pilot.Delete(tx)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to purge pilot", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to purge Pilot"))
+		c.Abort()
+	} else {
+		log.Info("db: purged pilot", "id", id)
+		c.JSON(204, gin.H{"message": "Pilot purged", "id": pilot.ID})
+	}
+}
+
+// PurgeJet : Permanently deletes the soft deleted jet matching the passed
+// id, locking it like PurgePilot
+func (route AdminRoutes) PurgeJet(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to purge Jet"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	jet, err := models.Jets(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if !jet.DeletedAt.Valid {
+		log.Error("db: purging jet that isn't deleted", "id", id)
+		c.Error(problem.New(409, "Jet must be deleted before it's purged"))
+		c.Abort()
+		return
+	}
+
+	if err = jet.Delete(tx); err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to purge jet", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to purge Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: purged jet", "id", id)
+		c.JSON(204, gin.H{"message": "Jet purged", "id": jet.ID})
+	}
+}
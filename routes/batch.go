@@ -0,0 +1,139 @@
+package routes
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/vattle/sqlboiler/boil"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// maxBatchItems : Most items a single batch request may hold
+const maxBatchItems = 1000
+
+// batchResult : Outcome of one item of a batch. Creates that failed have no
+// id, so results are matched to items by their position in the list
+type batchResult struct {
+	Status int              `json:"status"`
+	ID     int              `json:"id,omitempty"`
+	Error  *problem.Problem `json:"error,omitempty"`
+}
+
+// batch : Runs the items of a batch request against exec, which is a
+// transaction when ?atomic=true. Atomic batches stop at the first failure,
+// which is kept in err so finish can roll everything back
+type batch struct {
+	atomic bool
+	exec   boil.Executor
+	tx     *sql.Tx
+	err    *problem.Problem
+}
+
+// customMethod : gin can't route a literal colon, so custom methods like
+// /pilots:batch are registered as a param named after the method. Writes a
+// 404 and returns false unless the param holds the expected :method suffix
+func customMethod(c *gin.Context, log log15.Logger, method string) bool {
+	if c.Param(method) == ":"+method {
+		return true
+	}
+
+	log.Error("gin: unknown custom method", "path", c.Request.URL.Path)
+	c.Error(problem.New(404, "No route matches "+c.Request.URL.Path))
+	c.Abort()
+	return false
+}
+
+// beginBatch : Parses ?atomic= and starts the transaction for an atomic batch
+// of n items, writing a 400 (or 500) and returning false when it can't run
+func beginBatch(c *gin.Context, db *sql.DB, log log15.Logger, n int) (*batch, bool) {
+	atomic, ok := queryBool(c, log, "atomic")
+	if !ok {
+		return nil, false
+	}
+
+	if n == 0 || n > maxBatchItems {
+		log.Error("gin: invalid batch size", "items", n)
+		c.Error(problem.New(400, fmt.Sprintf("Batch must hold between 1 and %d items", maxBatchItems)))
+		c.Abort()
+		return nil, false
+	}
+
+	b := &batch{atomic: atomic, exec: db}
+	if atomic {
+		tx, err := db.Begin()
+		if err != nil {
+			log.Error("db: failed to begin transaction", "err", err)
+			c.Error(problem.Wrap(err, 500, "Failed to run batch"))
+			c.Abort()
+			return nil, false
+		}
+		b.tx, b.exec = tx, tx
+	}
+
+	return b, true
+}
+
+// run : Calls fn for each of n items of the op list, collecting a result per
+// item with status, or the problem fn failed with
+func (b *batch) run(log log15.Logger, op string, status, n int, fn func(i int) (int, error)) []batchResult {
+	results := make([]batchResult, 0, n)
+	for i := 0; i < n && b.err == nil; i++ {
+		id, err := fn(i)
+		if err == nil {
+			results = append(results, batchResult{Status: status, ID: id})
+			continue
+		}
+
+		p := problem.From(err)
+		log.Error("db: batch item failed", "op", op, "index", i, "status", p.Status, "err", err)
+		if b.atomic {
+			b.err = p.With("operation", op).With("index", i)
+		}
+		results = append(results, batchResult{Status: p.Status, ID: id, Error: p})
+	}
+
+	return results
+}
+
+// fail : Marks every result of op that had succeeded as failed with err, for
+// statements run once for the whole list after the items were checked
+func (b *batch) fail(log log15.Logger, op string, results []batchResult, status int, err error) {
+	p := problem.From(err)
+	log.Error("db: batch failed", "op", op, "err", err)
+	if b.atomic {
+		b.err = p.With("operation", op)
+		return
+	}
+
+	for i := range results {
+		if results[i].Status == status {
+			results[i].Status, results[i].Error = p.Status, p
+		}
+	}
+}
+
+// finish : Commits an atomic batch and writes the results, or writes the
+// failure that rolled the batch back
+func (b *batch) finish(c *gin.Context, log log15.Logger, resp gin.H) {
+	if b.tx != nil {
+		if b.err == nil {
+			if err := b.tx.Commit(); err != nil {
+				b.err = problem.Wrap(err, 500, "Failed to commit batch")
+			}
+		} else {
+			b.tx.Rollback()
+		}
+	}
+
+	if b.err != nil {
+		log.Error("db: batch rolled back", "status", b.err.Status)
+		c.Error(b.err)
+		c.Abort()
+		return
+	}
+
+	log.Info("db: ran batch", "atomic", b.atomic)
+	c.JSON(200, resp)
+}
@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cursorKey signs cursor tokens so clients can't forge arbitrary positions.
+// Set a cursor secret when running more than one instance, otherwise cursors
+// are only valid for the process that issued them
+var cursorKey = randomKey()
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// SetCursorSecret : Signs cursors with secret instead of a per process key
+func SetCursorSecret(secret string) {
+	if secret != "" {
+		cursorKey = []byte(secret)
+	}
+}
+
+func randomKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// encodeCursor : Signs the last seen id of resource into an opaque token
+func encodeCursor(resource string, id int) string {
+	payload := []byte(fmt.Sprintf("%s:%d", resource, id))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signCursor(payload))
+}
+
+// decodeCursor : Verifies a token from encodeCursor and returns the id it holds
+func decodeCursor(resource string, token string) (int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, errInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(mac, signCursor(payload)) {
+		return 0, errInvalidCursor
+	}
+
+	prefix := resource + ":"
+	if !strings.HasPrefix(string(payload), prefix) {
+		return 0, errInvalidCursor
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(string(payload), prefix))
+	if err != nil || id < 0 {
+		return 0, errInvalidCursor
+	}
+
+	return id, nil
+}
+
+func signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, cursorKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// etag : Strong entity tag for v, a hash of its JSON rendering
+func etag(v interface{}) string {
+	data, _ := json.Marshal(v)
+	sum := sha1.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// notModified : Writes the ETag header for resp, writing a 304 and returning
+// true when it matches If-None-Match. Partial representations (?fields= or
+// ?include=) get a weak tag, as they can't be used with If-Match
+func notModified(c *gin.Context, resp interface{}, partial bool) bool {
+	tag := etag(resp)
+	if partial {
+		tag = "W/" + tag
+	}
+	c.Header("ETag", tag)
+
+	if matchTags(c.GetHeader("If-None-Match"), tag, true) {
+		c.Status(304)
+		return true
+	}
+
+	return false
+}
+
+// ifMatch : Compares If-Match against the current ETag of v, writing a 412
+// and returning false when it doesn't match. Requests without If-Match
+// always pass
+func ifMatch(c *gin.Context, log log15.Logger, resource string, v interface{}) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" || matchTags(header, etag(v), false) {
+		return true
+	}
+
+	log.Error("gin: "+strings.ToLower(resource)+" etag mismatch", "if_match", header)
+	c.Error(problem.New(412, resource+" has been modified"))
+	c.Abort()
+	return false
+}
+
+// matchTags : Reports whether the comma separated list of tags in header
+// matches tag. Weak comparison ignores W/ prefixes, strong comparison never
+// matches a weak tag
+func matchTags(header, tag string, weak bool) bool {
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+				return true
+			}
+		} else if candidate == tag && !strings.HasPrefix(tag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// fieldset : Columns requested through the fields query param. An empty
+// fieldset selects and renders every column
+type fieldset struct {
+	fields  []string
+	columns []string
+}
+
+// sparseFields : Parses the comma separated fields query param, writing a 400
+// and returning false when it names a column that isn't in columns
+func sparseFields(c *gin.Context, log log15.Logger, columns []string) (fieldset, bool) {
+	fs := fieldset{columns: columns}
+
+	param := c.Query("fields")
+	if param == "" {
+		return fs, true
+	}
+
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if !contains(columns, field) {
+			log.Error("gin: invalid fields", "fields", param)
+			c.Error(problem.New(400, "unknown field: "+field))
+			c.Abort()
+			return fs, false
+		}
+		if !contains(fs.fields, field) {
+			fs.fields = append(fs.fields, field)
+		}
+	}
+
+	return fs, true
+}
+
+// selectCols : Columns to fetch from the db, or nil for all of them. keys are
+// always fetched so cursors and relationships can still be resolved, they're
+// dropped again by project when they weren't asked for
+func (fs fieldset) selectCols(keys ...string) []string {
+	if len(fs.fields) == 0 {
+		return nil
+	}
+
+	cols := append([]string{}, fs.fields...)
+	for _, key := range keys {
+		if !contains(cols, key) {
+			cols = append(cols, key)
+		}
+	}
+
+	return cols
+}
+
+// project : Renders v with only the requested columns, leaving any embedded
+// relationships intact
+func (fs fieldset) project(v interface{}) interface{} {
+	if len(fs.fields) == 0 {
+		return v
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return v
+	}
+
+	for key := range obj {
+		if contains(fs.columns, key) && !contains(fs.fields, key) {
+			delete(obj, key)
+		}
+	}
+
+	return obj
+}
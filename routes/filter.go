@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/vattle/sqlboiler/queries/qm"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+var filterParam = regexp.MustCompile(`^filter\[(\w+)\](?:\[(\w+)\])?$`)
+
+var filterOps = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+	"in":   "IN",
+}
+
+// filters : Translates filter[column]=value and filter[column][op]=value query
+// params into where mods, writing a 400 and returning false for columns not in
+// columns, unknown operators or values that don't match the column's type
+func filters(c *gin.Context, log log15.Logger, model interface{}, columns []string) ([]qm.QueryMod, bool) {
+	kinds := columnKinds(model)
+	query := c.Request.URL.Query()
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var mods []qm.QueryMod
+	for _, key := range keys {
+		match := filterParam.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+
+		column, op := match[1], match[2]
+		if op == "" {
+			op = "eq"
+		}
+
+		if !contains(columns, column) {
+			return nil, filterError(c, log, "Unknown filter column: "+column)
+		}
+		sqlOp, ok := filterOps[op]
+		if !ok {
+			return nil, filterError(c, log, "Unknown filter operator: "+op)
+		}
+		if op == "like" && kinds[column] != reflect.String {
+			return nil, filterError(c, log, "like filter requires a text column: "+column)
+		}
+
+		for _, value := range query[key] {
+			var args []interface{}
+			if op == "in" {
+				for _, v := range strings.Split(value, ",") {
+					arg, err := filterArg(kinds[column], v)
+					if err != nil {
+						return nil, filterError(c, log, "Invalid filter value for "+column)
+					}
+					args = append(args, arg)
+				}
+				mods = append(mods, qm.WhereIn(fmt.Sprintf("\"%s\" IN ?", column), args...))
+				continue
+			}
+
+			arg, err := filterArg(kinds[column], value)
+			if err != nil {
+				return nil, filterError(c, log, "Invalid filter value for "+column)
+			}
+			mods = append(mods, qm.Where(fmt.Sprintf("\"%s\" %s ?", column, sqlOp), arg))
+		}
+	}
+
+	return mods, true
+}
+
+func filterError(c *gin.Context, log log15.Logger, message string) bool {
+	log.Error("gin: invalid filter", "err", message)
+	c.Error(problem.New(400, message))
+	c.Abort()
+	return false
+}
+
+func filterArg(kind reflect.Kind, value string) (interface{}, error) {
+	switch kind {
+	case reflect.Int, reflect.Int64:
+		return strconv.Atoi(value)
+	default:
+		return value, nil
+	}
+}
+
+// columnKinds : Maps each boil tagged column of model to its Go kind
+func columnKinds(model interface{}) map[string]reflect.Kind {
+	kinds := make(map[string]reflect.Kind)
+
+	t := reflect.Indirect(reflect.ValueOf(model)).Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag := field.Tag.Get("boil"); tag != "" && tag != "-" {
+			kinds[tag] = field.Type.Kind()
+		}
+	}
+
+	return kinds
+}
@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/models"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// readyTimeout : Longest the database checks of a readiness probe may take
+const readyTimeout = 2 * time.Second
+
+// HealthRoutes : Probes for the orchestrator. Unlike the other routes it
+// holds state, so it must be shared by pointer
+type HealthRoutes struct {
+	draining int32
+}
+
+// check : Outcome of one readiness check
+type check struct {
+	Status  string  `json:"status"`
+	Latency float64 `json:"latency_ms"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// schema : Columns the code expects each table to have. A database missing
+// any of them hasn't had postgres/migrations applied
+func schema() map[string][]string {
+	return map[string][]string{
+		"pilots":           models.PilotColumns(),
+		"jets":             models.JetColumns(),
+		"languages":        models.LanguageColumns(),
+		"idempotency_keys": {"key", "path", "request_hash", "status", "content_type", "body", "created_at"},
+	}
+}
+
+// Drain : Fails every readiness probe from now on, so traffic is routed
+// elsewhere before the server shuts down
+func (route *HealthRoutes) Drain() {
+	atomic.StoreInt32(&route.draining, 1)
+}
+
+// Live : Reports the process is up and serving requests
+func (route *HealthRoutes) Live(c *gin.Context) {
+	c.JSON(200, gin.H{"status": "alive"})
+}
+
+// Ready : Reports whether the server should receive traffic. The database
+// must answer a ping within readyTimeout, its schema must be up to date and
+// shutdown mustn't have started, otherwise a 503 lists the failed checks
+func (route *HealthRoutes) Ready(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+	defer cancel()
+
+	checks := map[string]check{
+		"database": timeCheck(func() error {
+			return db.PingContext(ctx)
+		}),
+		"migrations": timeCheck(func() error {
+			return checkSchema(ctx, db)
+		}),
+		"shutdown": timeCheck(func() error {
+			if atomic.LoadInt32(&route.draining) == 1 {
+				return fmt.Errorf("server is shutting down")
+			}
+			return nil
+		}),
+	}
+
+	status, code := "ready", 200
+	for name, result := range checks {
+		if result.Status != "ok" {
+			log.Warn("gin: readiness check failed", "check", name, "err", result.Error)
+			status, code = "unavailable", 503
+		}
+	}
+
+	c.JSON(code, gin.H{"status": status, "checks": checks})
+}
+
+// timeCheck : Runs fn, recording how long it took and whether it failed
+func timeCheck(fn func() error) check {
+	start := time.Now()
+	err := fn()
+
+	result := check{Status: "ok", Latency: float64(time.Since(start)) / float64(time.Millisecond)}
+	if err != nil {
+		result.Status, result.Error = "fail", err.Error()
+	}
+	return result
+}
+
+// checkSchema : Fails listing every expected column that's missing from db
+func checkSchema(ctx context.Context, db *sql.DB) error {
+	expected := schema()
+
+	tables := make([]interface{}, 0, len(expected))
+	placeholders := make([]string, 0, len(expected))
+	for table := range expected {
+		tables = append(tables, table)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(tables)))
+	}
+
+	rows, err := db.QueryContext(ctx, `select table_name, column_name from information_schema.columns
+		where table_schema = current_schema() and table_name in (`+strings.Join(placeholders, ", ")+`)`, tables...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return err
+		}
+		found[table+"."+column] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var missing []string
+	for table, columns := range expected {
+		for _, column := range columns {
+			if !found[table+"."+column] {
+				missing = append(missing, table+"."+column)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("pending migrations, missing columns: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
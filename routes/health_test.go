@@ -0,0 +1,94 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/magiconair/properties/assert"
+	"github.com/phazyy/golang-rest-api/config"
+	"github.com/phazyy/golang-rest-api/middleware"
+	"github.com/phazyy/golang-rest-api/routes"
+)
+
+// TestLive : Assert liveness probe - must return 200
+func TestLive(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/healthz", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestReady : Assert readiness probe reports every check - must return 200
+func TestReady(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/readyz", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		Status string `json:"status"`
+		Checks map[string]struct {
+			Status string `json:"status"`
+		} `json:"checks"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, resp.Status, "ready")
+	assert.Equal(t, resp.Checks["database"].Status, "ok")
+	assert.Equal(t, resp.Checks["migrations"].Status, "ok")
+	assert.Equal(t, resp.Checks["shutdown"].Status, "ok")
+}
+
+// TestReadyDraining : Assert readiness fails once shutdown starts - must return 503
+func TestReadyDraining(t *testing.T) {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	db, err := middleware.OpenDatabase(cfg.Postgres)
+	if err != nil {
+		panic(err)
+	}
+
+	r := gin.New()
+	r.Use(middleware.Logger(log))
+	r.Use(middleware.Database(db))
+	health := new(routes.HealthRoutes)
+	r.GET("/readyz", health.Ready)
+	health.Drain()
+
+	req, err := http.NewRequest("GET", "/readyz", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	resp := struct {
+		Checks map[string]struct {
+			Status string `json:"status"`
+		} `json:"checks"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 503)
+	assert.Equal(t, resp.Checks["shutdown"].Status, "fail")
+}
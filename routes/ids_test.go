@@ -0,0 +1,96 @@
+package routes_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// missingID : An id no test fixture will reach
+const missingID = 2147483000
+
+var idRoutes = []struct {
+	method string
+	path   string
+	body   string
+}{
+	{"GET", "/v1/pilots/%v", ""},
+	{"PUT", "/v1/pilots/%v", `{"name": "Iceman"}`},
+	{"PATCH", "/v1/pilots/%v", `{"name": "Iceman"}`},
+	{"DELETE", "/v1/pilots/%v", ""},
+	{"POST", "/v1/pilots/%v/restore", ""},
+	{"GET", "/v1/pilots/%v/jets", ""},
+	{"POST", "/v1/pilots/%v/jets", `{"age": 1, "name": "Tomcat", "color": "white"}`},
+	{"GET", "/v1/pilots/%v/languages", ""},
+	{"PUT", "/v1/pilots/%v/languages/1", ""},
+	{"DELETE", "/v1/pilots/%v/languages/1", ""},
+	{"GET", "/v1/jets/%v", ""},
+	{"PUT", "/v1/jets/%v", `{"pilot_id": 1, "age": 1, "name": "Tomcat", "color": "white"}`},
+	{"PATCH", "/v1/jets/%v", `{"color": "white"}`},
+	{"DELETE", "/v1/jets/%v", ""},
+	{"POST", "/v1/jets/%v/restore", ""},
+	{"PUT", "/v1/jets/%v/pilot", `{"pilot_id": 1}`},
+	{"GET", "/v1/languages/%v", ""},
+	{"PUT", "/v1/languages/%v", `{"language": "German"}`},
+	{"DELETE", "/v1/languages/%v", ""},
+	{"DELETE", "/v1/admin/pilots/%v", ""},
+	{"DELETE", "/v1/admin/jets/%v", ""},
+}
+
+// TestMalformedIDs : Assert every :id route rejects non integer ids - must return 400
+func TestMalformedIDs(t *testing.T) {
+	testRouter := SetupRouter()
+
+	for _, route := range idRoutes {
+		for _, id := range []string{"abc", "0", "-1", "1.5"} {
+			url := fmt.Sprintf(route.path, id)
+			req, err := http.NewRequest(route.method, url, bytes.NewBufferString(route.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			res := httptest.NewRecorder()
+
+			testRouter.ServeHTTP(res, req)
+			assert.Equal(t, res.Code, 400, route.method+" "+url)
+		}
+	}
+}
+
+// TestMissingIDs : Assert every :id route reports unknown ids - must return 404, or 401 for admin routes without the token
+func TestMissingIDs(t *testing.T) {
+	testRouter := SetupRouter()
+
+	for _, route := range idRoutes {
+		url := fmt.Sprintf(route.path, missingID)
+		req, err := http.NewRequest(route.method, url, bytes.NewBufferString(route.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, 404, route.method+" "+url)
+
+		if strings.HasPrefix(route.path, "/v1/admin/") {
+			// Without the token the route must not even reveal the id is missing
+			for _, auth := range []string{"", "Bearer wrong"} {
+				req.Header.Set("Authorization", auth)
+				res = httptest.NewRecorder()
+
+				testRouter.ServeHTTP(res, req)
+				assert.Equal(t, res.Code, 401, route.method+" "+url+" "+auth)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/models"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// pilotWithRelations : Pilot JSON with any eager loaded relationships embedded
+type pilotWithRelations struct {
+	*models.Pilot
+	Jets *models.JetSlice `json:"jets,omitempty"`
+}
+
+// jetWithRelations : Jet JSON with any eager loaded relationships embedded
+type jetWithRelations struct {
+	*models.Jet
+	Pilot *models.Pilot `json:"pilot,omitempty"`
+}
+
+// includes : Parses the comma separated include query param, writing a 400
+// and returning false when it names a relationship that isn't allowed
+func includes(c *gin.Context, log log15.Logger, allowed ...string) (map[string]bool, bool) {
+	include := make(map[string]bool)
+
+	param := c.Query("include")
+	if param == "" {
+		return include, true
+	}
+
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if !contains(allowed, name) {
+			log.Error("gin: unknown include", "include", name)
+			c.Error(problem.New(400, "Unknown include: "+name))
+			c.Abort()
+			return nil, false
+		}
+		include[name] = true
+	}
+
+	return include, true
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withPilotRelations(pilot *models.Pilot, include map[string]bool) pilotWithRelations {
+	resp := pilotWithRelations{Pilot: pilot}
+	if include["jets"] {
+		jets := models.JetSlice{}
+		if pilot.R != nil {
+			// Eager loading doesn't hide soft deleted jets
+			for _, jet := range pilot.R.Jets {
+				if !jet.DeletedAt.Valid {
+					jets = append(jets, jet)
+				}
+			}
+		}
+		resp.Jets = &jets
+	}
+
+	return resp
+}
+
+func withJetRelations(jet *models.Jet, include map[string]bool) jetWithRelations {
+	resp := jetWithRelations{Jet: jet}
+	if include["pilot"] && jet.R != nil && jet.R.Pilot != nil && !jet.R.Pilot.DeletedAt.Valid {
+		resp.Pilot = jet.R.Pilot
+	}
+
+	return resp
+}
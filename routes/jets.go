@@ -0,0 +1,570 @@
+package routes
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/models"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/pkg/errors"
+	"github.com/vattle/sqlboiler/queries/qm"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// JetRoutes :
+type JetRoutes struct{}
+
+// Get : Attempts to fetch a single jet matching passed ID
+// The owning pilot is embedded when passed ?include=pilot
+// Only the columns named by ?fields= are returned when passed
+// Soft deleted jets are only found when passed ?include_deleted=true
+// Responds with a 304 when If-None-Match matches the jet's ETag
+func (route JetRoutes) Get(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	include, ok := includes(c, log, "pilot")
+	if !ok {
+		return
+	}
+
+	fields, ok := sparseFields(c, log, models.JetColumns())
+	if !ok {
+		return
+	}
+
+	withDeleted, ok := includeDeleted(c, log)
+	if !ok {
+		return
+	}
+
+	id := c.GetInt("id")
+
+	var jet *models.Jet
+	var err error
+	if withDeleted {
+		jet, err = models.Jets(db, qm.Select(fields.selectCols("id", "pilot_id")...), qm.Where("id = ?", id)).One()
+	} else {
+		jet, err = models.FindActiveJet(db, id, fields.selectCols("id", "pilot_id")...)
+	}
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if include["pilot"] {
+		if err := jet.L.LoadPilot(db, true, jet); err != nil {
+			log.Error("db: failed to load jet pilot", "id", id, "err", err)
+			c.Error(problem.Wrap(err, 500, "Failed to fetch Pilot"))
+			c.Abort()
+			return
+		}
+	}
+
+	resp := fields.project(withJetRelations(jet, include))
+	if notModified(c, resp, len(fields.fields) > 0 || len(include) > 0) {
+		log.Info("db: jet not modified", "id", id)
+		return
+	}
+
+	log.Info("db: fetched jet", "id", id)
+	c.JSON(200, resp)
+}
+
+// GetAll : Get all jets
+// The owning pilots are embedded when passed ?include=pilot
+// Results are narrowed by filter[column][op]=value params
+// Only the columns named by ?fields= are returned when passed
+// Soft deleted jets are only listed when passed ?include_deleted=true
+func (route JetRoutes) GetAll(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	include, ok := includes(c, log, "pilot")
+	if !ok {
+		return
+	}
+
+	fields, ok := sparseFields(c, log, models.JetColumns())
+	if !ok {
+		return
+	}
+
+	pg, ok := paginateKeyset(c, log, "jets", models.JetColumns())
+	if !ok {
+		return
+	}
+
+	where, ok := filters(c, log, models.Jet{}, models.JetColumns())
+	if !ok {
+		return
+	}
+
+	withDeleted, ok := includeDeleted(c, log)
+	if !ok {
+		return
+	}
+
+	query := models.ActiveJets
+	if withDeleted {
+		query = models.Jets
+	}
+
+	total, err := query(db, where...).Count()
+	if err != nil {
+		log.Error("db: failed to count jets", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Jets"))
+		c.Abort()
+		return
+	}
+
+	mods := append(pg.mods(), where...)
+	if cols := fields.selectCols("id", "pilot_id"); cols != nil {
+		mods = append(mods, qm.Select(cols...))
+	}
+	if include["pilot"] {
+		mods = append(mods, qm.Load("Pilot"))
+	}
+
+	jets, err := query(db, mods...).All()
+	if err != nil {
+		log.Error("db: failed to get jets", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Jets"))
+		c.Abort()
+		return
+	}
+
+	lastID := 0
+	resp := make([]interface{}, len(jets))
+	for i, jet := range jets {
+		resp[i] = fields.project(withJetRelations(jet, include))
+		lastID = jet.ID
+	}
+
+	log.Info("db: fetched jets", "count", len(jets), "total", total)
+	pg.render(c, total, resp, len(jets), lastID)
+}
+
+// Create : Create a jet owned by the pilot matching pilot_id
+func (route JetRoutes) Create(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	var jet models.Jet
+	if c.BindJSON(&jet) != nil {
+		log.Error("gin: error creating jet")
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	if !validate(c, log, &jet, jetRules) {
+		return
+	}
+
+	// The database assigns ids, one in the body is ignored
+	jet.ID = 0
+	jet.DeletedAt.Valid = false
+
+	if !pilotExists(c, db, log, jet.PilotID) {
+		return
+	}
+
+	if err := jet.Insert(db); err != nil {
+		log.Error("db: failed to insert jet", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to insert Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: inserted jet", "id", jet.ID)
+		c.JSON(201, gin.H{"message": "Jet created", "id": jet.ID})
+	}
+}
+
+// Update : Attempts to update the jet matching the passed id. The jet is
+// locked while If-Match is checked against its ETag, so concurrent writers
+// get a 412 rather than silently overwriting each other
+func (route JetRoutes) Update(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+	var json models.Jet
+	if c.BindJSON(&json) != nil {
+		log.Error("gin: error updating jet", "id", id)
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	if !validate(c, log, &json, jetRules) {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Jet"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	jet, err := models.ActiveJets(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Jet", jet) || !pilotExists(c, db, log, json.PilotID) {
+		return
+	}
+
+	jet.PilotID = json.PilotID
+	jet.Age = json.Age
+	jet.Name = json.Name
+	jet.Color = json.Color
+	if err = jet.Update(tx); err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to update jet", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: updated jet", "id", id)
+		c.Header("ETag", etag(jet))
+		c.JSON(200, gin.H{"message": "Jet updated", "id": jet.ID})
+	}
+}
+
+// Patch : Applies a JSON merge patch, or a JSON Patch when sent as
+// application/json-patch+json, to the jet matching the passed id. The jet is
+// locked for the duration so test ops and If-Match can't race other writers,
+// and only the columns the patch touches are updated
+func (route JetRoutes) Patch(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Jet"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	jet, err := models.ActiveJets(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Jet", jet) {
+		return
+	}
+
+	cols, ok := applyPatch(c, log, jet, models.JetColumns())
+	if !ok || !validate(c, log, jet, jetRules, cols...) {
+		return
+	}
+
+	if contains(cols, "pilot_id") && !pilotExists(c, db, log, jet.PilotID) {
+		return
+	}
+
+	if len(cols) > 0 {
+		if err := jet.Update(tx, cols...); err != nil {
+			log.Error("db: failed to patch jet", "id", id, "err", err)
+			c.Error(problem.Wrap(err, 500, "Failed to update Jet"))
+			c.Abort()
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("db: failed to commit jet patch", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Jet"))
+		c.Abort()
+		return
+	}
+
+	log.Info("db: patched jet", "id", id, "columns", cols)
+	c.Header("ETag", etag(jet))
+	c.JSON(200, gin.H{"message": "Jet updated", "id": jet.ID})
+}
+
+// Delete : Attempts to soft delete the jet matching the passed id, which can
+// be undone with Restore. A 412 is returned when If-Match doesn't match the
+// jet's ETag
+func (route JetRoutes) Delete(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to delete Jet"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	jet, err := models.ActiveJets(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Jet", jet) {
+		return
+	}
+
+	if err = jet.SoftDelete(tx, time.Now()); err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to delete jet", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to delete Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: deleted jet", "id", id)
+		c.JSON(204, gin.H{"message": "Jet deleted", "id": jet.ID})
+	}
+}
+
+// Restore : Restores the soft deleted jet matching the passed id, provided
+// its pilot hasn't been deleted too
+func (route JetRoutes) Restore(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	jet, err := models.Jets(db, qm.Where("id = ?", id)).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if !jet.DeletedAt.Valid {
+		log.Error("db: jet isn't deleted", "id", id)
+		c.Error(problem.New(409, "Jet isn't deleted"))
+		c.Abort()
+		return
+	}
+
+	if !pilotExists(c, db, log, jet.PilotID) {
+		return
+	}
+
+	if err := jet.Restore(db); err != nil {
+		log.Error("db: failed to restore jet", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to restore Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: restored jet", "id", id)
+		c.JSON(200, gin.H{"message": "Jet restored", "id": jet.ID})
+	}
+}
+
+// jetBatch : Body of a jet batch, every list is optional
+type jetBatch struct {
+	Create []models.Jet `json:"create"`
+	Update []models.Jet `json:"update"`
+	Delete []int        `json:"delete"`
+}
+
+// Batch : Creates, updates and soft deletes many jets in one request,
+// responding with a result per item in the order they were sent. With
+// ?atomic=true everything runs in one transaction and the first failure rolls
+// it all back, otherwise each item succeeds or fails on its own
+func (route JetRoutes) Batch(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	if !customMethod(c, log, "batch") {
+		return
+	}
+
+	var body jetBatch
+	if c.BindJSON(&body) != nil {
+		log.Error("gin: error running jet batch")
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	b, ok := beginBatch(c, db, log, len(body.Create)+len(body.Update)+len(body.Delete))
+	if !ok {
+		return
+	}
+
+	created := b.run(log, "create", 201, len(body.Create), func(i int) (int, error) {
+		jet := body.Create[i]
+		jet.ID = 0
+		jet.DeletedAt.Valid = false
+		if errs := jetRules.check(&jet); len(errs) > 0 {
+			return 0, problem.Invalid(errs)
+		}
+
+		if err := batchPilotExists(b, jet.PilotID); err != nil {
+			return 0, err
+		}
+
+		if err := jet.Insert(b.exec); err != nil {
+			return 0, problem.Wrap(err, 500, "Failed to insert Jet")
+		}
+		return jet.ID, nil
+	})
+
+	updated := b.run(log, "update", 200, len(body.Update), func(i int) (int, error) {
+		json := body.Update[i]
+		if errs := jetRules.check(&json); len(errs) > 0 {
+			return json.ID, problem.Invalid(errs)
+		}
+
+		jet, err := models.FindActiveJet(b.exec, json.ID)
+		if err != nil {
+			return json.ID, lookupError(err, "Jet")
+		}
+
+		if err := batchPilotExists(b, json.PilotID); err != nil {
+			return jet.ID, err
+		}
+
+		jet.PilotID = json.PilotID
+		jet.Age = json.Age
+		jet.Name = json.Name
+		jet.Color = json.Color
+		if err := jet.Update(b.exec); err != nil {
+			return jet.ID, problem.Wrap(err, 500, "Failed to update Jet")
+		}
+		return jet.ID, nil
+	})
+
+	var jets models.JetSlice
+	deleted := b.run(log, "delete", 204, len(body.Delete), func(i int) (int, error) {
+		id := body.Delete[i]
+		jet, err := models.FindActiveJet(b.exec, id)
+		if err != nil {
+			return id, lookupError(err, "Jet")
+		}
+
+		jets = append(jets, jet)
+		return id, nil
+	})
+
+	// The checked jets are soft deleted together
+	if len(jets) > 0 && b.err == nil {
+		if err := jets.UpdateAll(b.exec, models.M{"deleted_at": time.Now()}); err != nil {
+			b.fail(log, "delete", deleted, 204, problem.Wrap(err, 500, "Failed to delete Jets"))
+		}
+	}
+
+	b.finish(c, log, gin.H{"create": created, "update": updated, "delete": deleted})
+}
+
+// SetPilot : Reassigns the jet matching the passed id to the pilot in the
+// body. Like Update the jet is locked while If-Match is checked
+func (route JetRoutes) SetPilot(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+	var json struct {
+		PilotID int `json:"pilot_id"`
+	}
+	if c.BindJSON(&json) != nil {
+		log.Error("gin: error reassigning jet", "id", id)
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to reassign Jet"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	jet, err := models.ActiveJets(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Jet", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Jet", jet) {
+		return
+	}
+
+	pilot, err := models.FindActivePilot(tx, json.PilotID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		log.Error("db: pilot doesn't exist", "id", json.PilotID)
+		c.Error(problem.New(422, "Pilot doesn't exist"))
+		c.Abort()
+		return
+	} else if err != nil {
+		log.Error("db: failed to get pilot", "id", json.PilotID, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to check Pilot"))
+		c.Abort()
+		return
+	}
+
+	if err = jet.SetPilot(tx, false, pilot); err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to reassign jet", "id", id, "pilot", pilot.ID, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to reassign Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: reassigned jet", "id", id, "pilot", pilot.ID)
+		c.Header("ETag", etag(jet))
+		c.JSON(200, gin.H{"message": "Jet reassigned", "id": jet.ID, "pilot_id": pilot.ID})
+	}
+}
+
+// batchPilotExists : The problem pilotExists would write for a batch item,
+// or nil when the pilot exists
+func batchPilotExists(b *batch, id int) error {
+	exists, err := models.ActivePilotExists(b.exec, id)
+	if err != nil {
+		return problem.Wrap(err, 500, "Failed to check Pilot")
+	}
+	if !exists {
+		return problem.New(422, "Pilot doesn't exist")
+	}
+
+	return nil
+}
+
+// pilotExists : Writes a 422 (or 500) and returns false when no pilot matches id
+func pilotExists(c *gin.Context, db *sql.DB, log log15.Logger, id int) bool {
+	exists, err := models.ActivePilotExists(db, id)
+	if err != nil {
+		log.Error("db: failed to check pilot", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to check Pilot"))
+		c.Abort()
+		return false
+	}
+	if !exists {
+		log.Error("db: pilot doesn't exist", "id", id)
+		c.Error(problem.New(422, "Pilot doesn't exist"))
+		c.Abort()
+		return false
+	}
+
+	return true
+}
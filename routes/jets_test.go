@@ -0,0 +1,840 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/phazyy/golang-rest-api/models"
+)
+
+var jetID int
+var jetPilotID int
+
+// TestCreateJet : Assert jet creation - must return 201
+func TestCreateJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	data, _ := json.Marshal(&models.Pilot{Name: "Maverick"})
+	req, err := http.NewRequest("POST", "/v1/pilots", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	pilot := struct {
+		Message string
+		ID      int
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &pilot)
+	jetPilotID = pilot.ID
+
+	testJet := &models.Jet{PilotID: jetPilotID, Age: 3, Name: "Hornet", Color: "grey"}
+	data, _ = json.Marshal(testJet)
+	req, err = http.NewRequest("POST", "/v1/jets", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	resp := struct {
+		Message string
+		ID      int
+	}{}
+
+	json.Unmarshal(body, &resp)
+	jetID = resp.ID
+
+	assert.Equal(t, res.Code, 201)
+}
+
+// TestCreateInvalidJet : Assert invalid jet create - must return 400
+func TestCreateInvalidJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("POST", "/v1/jets", bytes.NewBufferString("Test"))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestCreateJetIgnoresID : Assert jet create assigns its own id - must return 201 with a new id
+func TestCreateJetIgnoresID(t *testing.T) {
+	testRouter := SetupRouter()
+
+	// A pilot of its own, so the extra jet doesn't show up in other tests
+	pilotID, existingID := createPilotJet(testRouter, "Viper")
+	testJet := &models.Jet{ID: existingID, PilotID: pilotID, Age: 3, Name: "Hornet", Color: "grey"}
+
+	data, _ := json.Marshal(testJet)
+	req, err := http.NewRequest("POST", "/v1/jets", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 201)
+
+	resp := struct {
+		Message string
+		ID      int
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+	assert.Equal(t, resp.ID != existingID, true)
+}
+
+// TestCreateOrphanJet : Assert jet create with an unknown pilot - must return 422
+func TestCreateOrphanJet(t *testing.T) {
+	testRouter := SetupRouter()
+	testJet := &models.Jet{PilotID: jetPilotID + 1000, Age: 3, Name: "Hornet", Color: "grey"}
+
+	data, _ := json.Marshal(testJet)
+	req, err := http.NewRequest("POST", "/v1/jets", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 422)
+}
+
+// TestCreateUnvalidatedJet : Assert jet create with invalid fields - must return 422 listing each field
+func TestCreateUnvalidatedJet(t *testing.T) {
+	testRouter := SetupRouter()
+	testJet := &models.Jet{PilotID: jetPilotID, Age: -1, Name: " ", Color: "grey"}
+
+	data, _ := json.Marshal(testJet)
+	req, err := http.NewRequest("POST", "/v1/jets", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		Errors []struct {
+			Field   string
+			Message string
+		}
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 422)
+	assert.Equal(t, len(resp.Errors), 2)
+	assert.Equal(t, resp.Errors[0].Field, "age")
+	assert.Equal(t, resp.Errors[1].Field, "name")
+}
+
+// TestGetJet : Assert jet fetch - must return 200
+func TestGetJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	resp := models.Jet{}
+	json.Unmarshal(body, &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, resp.Name, "Hornet")
+	assert.Equal(t, resp.PilotID, jetPilotID)
+}
+
+// TestGetInvalidJet : Assert negative jet fetch - must return 404
+func TestGetInvalidJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID+1000)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestGetJets : Assert jet list fetch - must return 200
+func TestGetJets(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/jets", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestGetJetsFilter : Assert filtered jet fetch - must return 200
+func TestGetJetsFilter(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets?filter[pilot_id]=%d&filter[color]=grey&filter[age][gt]=2", jetPilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	var resp []models.Jet
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp), 1)
+	assert.Equal(t, resp[0].ID, jetID)
+}
+
+// TestGetJetsInvalidFilter : Assert filter on an unknown column - must return 400
+func TestGetJetsInvalidFilter(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/jets?filter[wings]=2", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestCreatePilotJet : Assert nested jet creation - must return 201
+func TestCreatePilotJet(t *testing.T) {
+	testRouter := SetupRouter()
+	testJet := &models.Jet{Age: 1, Name: "Tomcat", Color: "white"}
+
+	data, _ := json.Marshal(testJet)
+	url := fmt.Sprintf("/v1/pilots/%d/jets", jetPilotID)
+	req, err := http.NewRequest("POST", url, bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		ID      int
+		PilotID int `json:"pilot_id"`
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 201)
+	assert.Equal(t, resp.PilotID, jetPilotID)
+}
+
+// TestGetPilotJets : Assert nested jet fetch - must return 200
+func TestGetPilotJets(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d/jets", jetPilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	var resp []models.Jet
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp), 2)
+}
+
+// TestGetInvalidPilotJets : Assert nested jet fetch for a missing pilot - must return 404
+func TestGetInvalidPilotJets(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d/jets", jetPilotID+1000)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestGetPilotIncludeJets : Assert pilot fetch with embedded jets - must return 200
+func TestGetPilotIncludeJets(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d?include=jets", jetPilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		ID   int
+		Jets []models.Jet
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp.Jets), 2)
+}
+
+// TestGetJetIncludePilot : Assert jet fetch with embedded pilot - must return 200
+func TestGetJetIncludePilot(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d?include=pilot", jetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		ID    int
+		Pilot models.Pilot
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, resp.Pilot.ID, jetPilotID)
+}
+
+// TestGetJetsInvalidInclude : Assert unknown include - must return 400
+func TestGetJetsInvalidInclude(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/jets?include=wings", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestGetJetsSort : Assert sorted jet fetch - must return 200
+func TestGetJetsSort(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets?filter[pilot_id]=%d&sort=-age,name", jetPilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	var resp []models.Jet
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	for i := 1; i < len(resp); i++ {
+		assert.Equal(t, resp[i-1].Age >= resp[i].Age, true)
+	}
+}
+
+// TestGetJetsInvalidSort : Assert sort on an unknown field - must return 400
+func TestGetJetsInvalidSort(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/jets?sort=-wings", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestGetJetFields : Assert sparse jet fetch - must return 200 with only the named fields
+func TestGetJetFields(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d?fields=name,color", jetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	var resp map[string]interface{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp), 2)
+	assert.Equal(t, resp["name"], "Hornet")
+}
+
+// TestGetJetsInvalidFields : Assert fields naming an unknown column - must return 400
+func TestGetJetsInvalidFields(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/jets?fields=id,wings", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestSetJetPilot : Assert jet reassignment - must return 200
+func TestSetJetPilot(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d/pilot", jetID)
+	body := fmt.Sprintf(`{"pilot_id": %d}`, jetPilotID)
+	req, err := http.NewRequest("PUT", url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestSetJetInvalidPilot : Assert jet reassignment to a missing pilot - must return 422
+func TestSetJetInvalidPilot(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d/pilot", jetID)
+	body := fmt.Sprintf(`{"pilot_id": %d}`, jetPilotID+1000)
+	req, err := http.NewRequest("PUT", url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 422)
+}
+
+// TestSetJetPilotIfMatch : Assert jet reassignment guarded by If-Match - must return 412 when stale, then 200
+func TestSetJetPilotIfMatch(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	tag := res.Header().Get("ETag")
+
+	for _, test := range []struct {
+		ifMatch string
+		code    int
+	}{
+		{`"stale"`, 412},
+		{tag, 200},
+	} {
+		body := fmt.Sprintf(`{"pilot_id": %d}`, jetPilotID)
+		req, err := http.NewRequest("PUT", url+"/pilot", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", test.ifMatch)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, test.code, test.ifMatch)
+	}
+}
+
+// TestUpdateJet : Assert jet update - must return 200
+func TestUpdateJet(t *testing.T) {
+	testRouter := SetupRouter()
+	testJet := &models.Jet{PilotID: jetPilotID, Age: 4, Name: "Super Hornet", Color: "grey"}
+
+	data, _ := json.Marshal(testJet)
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("PUT", url, bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestUpdateInvalidJet : Assert update of a missing jet - must return 404
+func TestUpdateInvalidJet(t *testing.T) {
+	testRouter := SetupRouter()
+	testJet := &models.Jet{PilotID: jetPilotID, Age: 4, Name: "Super Hornet", Color: "grey"}
+
+	data, _ := json.Marshal(testJet)
+	url := fmt.Sprintf("/v1/jets/%d", jetID+1000)
+	req, err := http.NewRequest("PUT", url, bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestPatchJet : Assert jet merge patch - must return 200 and leave other fields alone
+func TestPatchJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("PATCH", url, bytes.NewBufferString(`{"color": "blue"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+
+	req, err = http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := models.Jet{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, resp.Color, "blue")
+	assert.Equal(t, resp.Name, "Super Hornet")
+}
+
+// TestPatchJetInvalidField : Assert merge patch of an unknown field - must return 400
+func TestPatchJetInvalidField(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("PATCH", url, bytes.NewBufferString(`{"wings": 2}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestJSONPatchJet : Assert jet JSON Patch with a passing test op - must return 200
+func TestJSONPatchJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	body := `[{"op": "test", "path": "/color", "value": "blue"}, {"op": "replace", "path": "/color", "value": "grey"}]`
+	req, err := http.NewRequest("PATCH", url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestJSONPatchJetFailedTest : Assert jet JSON Patch with a failing test op - must return 409
+func TestJSONPatchJetFailedTest(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	body := `[{"op": "test", "path": "/color", "value": "blue"}, {"op": "replace", "path": "/age", "value": 9}]`
+	req, err := http.NewRequest("PATCH", url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 409)
+}
+
+// TestDeleteJet : Assert jet deletion - must return 204
+func TestDeleteJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 204)
+}
+
+// TestDeleteInvalidJet : Assert deletion of a missing jet - must return 404
+func TestDeleteInvalidJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d", jetID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestGetDeletedJet : Assert soft deleted jet fetch with include_deleted - must return 200
+func TestGetDeletedJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d?include_deleted=true", jetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		DeletedAt *string `json:"deleted_at"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, resp.DeletedAt != nil, true)
+}
+
+// TestRestoreJet : Assert soft deleted jet restore - must return 200
+func TestRestoreJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/jets/%d/restore", jetID)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/v1/jets/%d", jetID), nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestPurgeActiveJet : Assert purging a jet that isn't deleted - must return 409
+func TestPurgeActiveJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/admin/jets/%d", jetID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 409)
+}
+
+// TestPurgeUnauthorized : Assert purging without the admin token - must return 401
+func TestPurgeUnauthorized(t *testing.T) {
+	testRouter := SetupRouter()
+
+	for _, auth := range []string{"", adminToken, "Bearer wrong"} {
+		req, err := http.NewRequest("DELETE", "/v1/admin/jets/1", nil)
+		req.Header.Set("Authorization", auth)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, 401, auth)
+	}
+}
+
+// TestPurgeJet : Assert purging a soft deleted jet - must return 204
+func TestPurgeJet(t *testing.T) {
+	testRouter := SetupRouter()
+
+	for _, url := range []string{"/v1/jets/%d", "/v1/admin/jets/%d"} {
+		req, err := http.NewRequest("DELETE", fmt.Sprintf(url, jetID), nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, 204)
+	}
+
+	url := fmt.Sprintf("/v1/jets/%d?include_deleted=true", jetID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestBatchJets : Assert best effort jet batch - must return 200 with a result per item
+func TestBatchJets(t *testing.T) {
+	testRouter := SetupRouter()
+	pilotID, jetID := createPilotJet(testRouter, "Slider")
+
+	body := fmt.Sprintf(`{
+		"create": [{"pilot_id": %d, "age": 3, "name": "Hornet", "color": "grey"}, {"id": %d, "pilot_id": %d, "age": 3, "name": "Hornet", "color": "grey"}],
+		"update": [{"id": %d, "pilot_id": %d, "age": 4, "name": "Tomcat", "color": "white"}],
+		"delete": [%d]
+	}`, pilotID, jetID, missingID, jetID, pilotID, jetID)
+	req, err := http.NewRequest("POST", "/v1/jets:batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := map[string][]struct {
+		Status int `json:"status"`
+		ID     int `json:"id"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, resp["create"][0].Status, 201)
+	assert.Equal(t, resp["create"][1].Status, 422)
+	assert.Equal(t, resp["create"][1].ID, 0)
+	assert.Equal(t, resp["update"][0].Status, 200)
+	assert.Equal(t, resp["delete"][0].Status, 204)
+}
+
+// createPilotJet : Creates a pilot named name owning a single jet
+func createPilotJet(testRouter http.Handler, name string) (int, int) {
+	data, _ := json.Marshal(&models.Pilot{Name: name})
+	req, _ := http.NewRequest("POST", "/v1/pilots", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	pilot := struct{ ID int }{}
+	json.Unmarshal(res.Body.Bytes(), &pilot)
+
+	data, _ = json.Marshal(&models.Jet{Age: 1, Name: "Tomcat", Color: "white"})
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/v1/pilots/%d/jets", pilot.ID), bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	jet := struct{ ID int }{}
+	json.Unmarshal(res.Body.Bytes(), &jet)
+
+	return pilot.ID, jet.ID
+}
@@ -0,0 +1,218 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/models"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/vattle/sqlboiler/queries/qm"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// LanguageRoutes :
+type LanguageRoutes struct{}
+
+// Get : Attempts to fetch a single language matching passed ID
+// Only the columns named by ?fields= are returned when passed
+// Responds with a 304 when If-None-Match matches the language's ETag
+func (route LanguageRoutes) Get(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	fields, ok := sparseFields(c, log, models.LanguageColumns())
+	if !ok {
+		return
+	}
+
+	id := c.GetInt("id")
+
+	language, err := models.FindLanguage(db, id, fields.selectCols()...)
+	if err != nil {
+		lookupFailed(c, log, err, "Language", id)
+		return
+	}
+
+	resp := fields.project(language)
+	if notModified(c, resp, len(fields.fields) > 0) {
+		log.Info("db: language not modified", "id", id)
+		return
+	}
+
+	log.Info("db: fetched language", "id", id)
+	c.JSON(200, resp)
+}
+
+// GetAll : Get all languages
+// Only the columns named by ?fields= are returned when passed
+func (route LanguageRoutes) GetAll(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	pg, ok := paginate(c, log, models.LanguageColumns())
+	if !ok {
+		return
+	}
+
+	fields, ok := sparseFields(c, log, models.LanguageColumns())
+	if !ok {
+		return
+	}
+
+	total, err := models.Languages(db).Count()
+	if err != nil {
+		log.Error("db: failed to count languages", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Languages"))
+		c.Abort()
+		return
+	}
+
+	mods := pg.mods()
+	if cols := fields.selectCols(); cols != nil {
+		mods = append(mods, qm.Select(cols...))
+	}
+
+	languages, err := models.Languages(db, mods...).All()
+	if err != nil {
+		log.Error("db: failed to get languages", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Languages"))
+		c.Abort()
+	} else {
+		log.Info("db: fetched languages", "count", len(languages), "total", total)
+		resp := make([]interface{}, len(languages))
+		for i, language := range languages {
+			resp[i] = fields.project(language)
+		}
+
+		pg.setHeaders(c, total)
+		c.JSON(200, resp)
+	}
+}
+
+// Create : Create language with the passed language string
+func (route LanguageRoutes) Create(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	var language models.Language
+	if c.BindJSON(&language) != nil {
+		log.Error("gin: error creating language")
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	if !validate(c, log, &language, languageRules) {
+		return
+	}
+
+	// The database assigns ids, one in the body is ignored
+	language.ID = 0
+	if err := language.Insert(db); err != nil {
+		log.Error("db: failed to insert language", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to insert Language"))
+		c.Abort()
+	} else {
+		log.Info("db: inserted language", "id", language.ID)
+		c.JSON(201, gin.H{"message": "Language created", "id": language.ID})
+	}
+}
+
+// Update : Attempts to update the language matching the passed id. The
+// language is locked while If-Match is checked against its ETag
+func (route LanguageRoutes) Update(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+	var json models.Language
+	if c.BindJSON(&json) != nil {
+		log.Error("gin: error updating language", "id", id)
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	if !validate(c, log, &json, languageRules) {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Language"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	language, err := models.Languages(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Language", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Language", language) {
+		return
+	}
+
+	language.Language = json.Language
+	if err = language.Update(tx); err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to update language", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Language"))
+		c.Abort()
+	} else {
+		log.Info("db: updated language", "id", id)
+		c.Header("ETag", etag(language))
+		c.JSON(200, gin.H{"message": "Language updated", "id": language.ID})
+	}
+}
+
+// Delete : Attempts to delete the language matching the passed id,
+// detaching it from any pilots that speak it. A 412 is returned when
+// If-Match doesn't match the language's ETag
+func (route LanguageRoutes) Delete(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to delete Language"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	language, err := models.Languages(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Language", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Language", language) {
+		return
+	}
+
+	if err = language.SetPilots(tx, false); err == nil {
+		err = language.Delete(tx)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to delete language", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to delete Language"))
+		c.Abort()
+	} else {
+		log.Info("db: deleted language", "id", id)
+		c.JSON(204, gin.H{"message": "Language deleted", "id": language.ID})
+	}
+}
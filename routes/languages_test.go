@@ -0,0 +1,274 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/phazyy/golang-rest-api/models"
+)
+
+var languageID int
+var languagePilotID int
+
+// TestCreateLanguage : Assert language creation - must return 201
+func TestCreateLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+	testLanguage := &models.Language{Language: "English"}
+
+	data, _ := json.Marshal(testLanguage)
+	req, err := http.NewRequest("POST", "/v1/languages", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	resp := struct {
+		Message string
+		ID      int
+	}{}
+
+	json.Unmarshal(body, &resp)
+	languageID = resp.ID
+
+	assert.Equal(t, res.Code, 201)
+}
+
+// TestCreateInvalidLanguage : Assert invalid language create - must return 400
+func TestCreateInvalidLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("POST", "/v1/languages", bytes.NewBufferString("Test"))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestCreateLanguageIgnoresID : Assert language create assigns its own id - must return 201 with a new id
+func TestCreateLanguageIgnoresID(t *testing.T) {
+	testRouter := SetupRouter()
+	testLanguage := &models.Language{ID: languageID, Language: "Klingon"}
+
+	data, _ := json.Marshal(testLanguage)
+	req, err := http.NewRequest("POST", "/v1/languages", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 201)
+
+	resp := struct {
+		Message string
+		ID      int
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+	assert.Equal(t, resp.ID != languageID, true)
+
+	// Remove the extra language so it doesn't show up in other tests
+	req, err = http.NewRequest("DELETE", fmt.Sprintf("/v1/languages/%d", resp.ID), nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 204)
+}
+
+// TestGetLanguage : Assert language fetch - must return 200
+func TestGetLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/languages/%d", languageID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := models.Language{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, resp.Language, "English")
+}
+
+// TestGetInvalidLanguage : Assert negative language fetch - must return 404
+func TestGetInvalidLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/languages/%d", languageID+1000)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestUpdateLanguage : Assert language update - must return 200
+func TestUpdateLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+	testLanguage := &models.Language{Language: "French"}
+
+	data, _ := json.Marshal(testLanguage)
+	url := fmt.Sprintf("/v1/languages/%d", languageID)
+	req, err := http.NewRequest("PUT", url, bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestAddPilotLanguage : Assert attaching a language to a pilot - must return 200
+func TestAddPilotLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	data, _ := json.Marshal(&models.Pilot{Name: "Goose"})
+	req, err := http.NewRequest("POST", "/v1/pilots", bytes.NewBufferString(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	pilot := struct {
+		Message string
+		ID      int
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &pilot)
+	languagePilotID = pilot.ID
+
+	url := fmt.Sprintf("/v1/pilots/%d/languages/%d", languagePilotID, languageID)
+	req, err = http.NewRequest("PUT", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
+
+// TestAddDuplicatePilotLanguage : Assert attaching a language twice - must return 409
+func TestAddDuplicatePilotLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d/languages/%d", languagePilotID, languageID)
+	req, err := http.NewRequest("PUT", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 409)
+}
+
+// TestGetPilotLanguages : Assert pilot language fetch - must return 200
+func TestGetPilotLanguages(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d/languages", languagePilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	var resp []models.Language
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp), 1)
+	assert.Equal(t, resp[0].Language, "French")
+}
+
+// TestRemovePilotLanguage : Assert detaching a language from a pilot - must return 204
+func TestRemovePilotLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d/languages/%d", languagePilotID, languageID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 204)
+}
+
+// TestRemoveInvalidPilotLanguage : Assert detaching an unattached language - must return 404
+func TestRemoveInvalidPilotLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d/languages/%d", languagePilotID, languageID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+}
+
+// TestDeleteLanguage : Assert language deletion - must return 204
+func TestDeleteLanguage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/languages/%d", languageID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 204)
+}
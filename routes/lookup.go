@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/pkg/errors"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// lookupFailed : Writes a 404 when fetching resource by id found no row, or a
+// 500 when the lookup itself failed
+func lookupFailed(c *gin.Context, log log15.Logger, err error, resource string, id int) {
+	if errors.Cause(err) == sql.ErrNoRows {
+		log.Error("db: "+strings.ToLower(resource)+" not found", "id", id)
+	} else {
+		log.Error("db: failed to get "+strings.ToLower(resource), "id", id, "err", err)
+	}
+	c.Error(lookupError(err, resource))
+	c.Abort()
+}
+
+// lookupError : The problem lookupFailed writes, for callers reporting it
+// somewhere other than the response
+func lookupError(err error, resource string) *problem.Problem {
+	if errors.Cause(err) == sql.ErrNoRows {
+		return problem.New(404, resource+" not found")
+	}
+	return problem.Wrap(err, 500, "Failed to fetch "+resource)
+}
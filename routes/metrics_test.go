@@ -0,0 +1,34 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// TestMetrics : Assert requests are counted by route template and pool stats exported - must return 200
+func TestMetrics(t *testing.T) {
+	testRouter := SetupRouter()
+
+	for _, url := range []string{"/v1/pilots/abc", "/v1/pilots/xyz", "/metrics"} {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+
+		if url == "/metrics" {
+			body := res.Body.String()
+			assert.Equal(t, res.Code, 200)
+			assert.Equal(t, strings.Contains(body, `http_requests_total{method="GET",route="/v1/pilots/:id",status="400"} 2`), true)
+			assert.Equal(t, strings.Contains(body, `http_request_duration_seconds_count{method="GET",route="/v1/pilots/:id",status="400"} 2`), true)
+			assert.Equal(t, strings.Contains(body, "go_sql_max_open_connections"), true)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package routes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/vattle/sqlboiler/queries/qm"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// page : Limit, offset and ordering of a collection request
+type page struct {
+	Limit  int
+	Offset int
+	Order  string
+
+	// Keyset mode, enabled by passing ?cursor=
+	Cursor   bool
+	After    int
+	resource string
+}
+
+// paginate : Parses the limit, offset and sort query params, writing a 400 and
+// returning false when limit isn't a positive integer, offset is negative or
+// sort names a column that isn't in columns. Limit is capped at maxLimit
+func paginate(c *gin.Context, log log15.Logger, columns []string) (page, bool) {
+	p := page{Limit: defaultLimit, Order: "id"}
+
+	if param := c.Query("limit"); param != "" {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit < 1 {
+			log.Error("gin: invalid limit", "limit", param)
+			c.Error(problem.New(400, "limit must be a positive integer"))
+			c.Abort()
+			return p, false
+		}
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+		p.Limit = limit
+	}
+
+	if param := c.Query("offset"); param != "" {
+		offset, err := strconv.Atoi(param)
+		if err != nil || offset < 0 {
+			log.Error("gin: invalid offset", "offset", param)
+			c.Error(problem.New(400, "offset must be a non-negative integer"))
+			c.Abort()
+			return p, false
+		}
+		p.Offset = offset
+	}
+
+	if param := c.Query("sort"); param != "" {
+		order, err := sortOrder(param, columns)
+		if err != nil {
+			log.Error("gin: invalid sort", "sort", param, "err", err)
+			c.Error(problem.New(400, err.Error()))
+			c.Abort()
+			return p, false
+		}
+		p.Order = order
+	}
+
+	return p, true
+}
+
+// sortOrder : Translates a sort param such as -age,name into an order by
+// clause, breaking ties on id so the ordering is deterministic
+func sortOrder(param string, columns []string) (string, error) {
+	var clauses []string
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		direction := ""
+		if strings.HasPrefix(field, "-") {
+			field = field[1:]
+			direction = " DESC"
+		}
+
+		if !contains(columns, field) {
+			return "", fmt.Errorf("unknown sort field: %s", field)
+		}
+		if seen[field] {
+			return "", fmt.Errorf("duplicate sort field: %s", field)
+		}
+		seen[field] = true
+
+		clauses = append(clauses, fmt.Sprintf("\"%s\"%s", field, direction))
+	}
+
+	if !seen["id"] {
+		clauses = append(clauses, "\"id\"")
+	}
+
+	return strings.Join(clauses, ", "), nil
+}
+
+// paginateKeyset : As paginate, additionally accepting an opaque cursor param
+// which pages by primary key after the id it holds. An empty cursor starts
+// from the first row
+func paginateKeyset(c *gin.Context, log log15.Logger, resource string, columns []string) (page, bool) {
+	p, ok := paginate(c, log, columns)
+	if !ok {
+		return p, false
+	}
+
+	token, set := c.GetQuery("cursor")
+	if !set {
+		return p, true
+	}
+
+	if c.Query("offset") != "" || c.Query("sort") != "" {
+		log.Error("gin: cursor passed with offset or sort")
+		c.Error(problem.New(400, "cursor can't be combined with offset or sort"))
+		c.Abort()
+		return p, false
+	}
+
+	p.Cursor = true
+	p.resource = resource
+	if token != "" {
+		after, err := decodeCursor(resource, token)
+		if err != nil {
+			log.Error("gin: invalid cursor", "cursor", token)
+			c.Error(problem.New(400, "Invalid cursor"))
+			c.Abort()
+			return p, false
+		}
+		p.After = after
+	}
+
+	return p, true
+}
+
+// mods : Query mods selecting the page in order
+func (p page) mods() []qm.QueryMod {
+	if p.Cursor {
+		return []qm.QueryMod{
+			qm.Where("id > ?", p.After),
+			qm.OrderBy("id"),
+			qm.Limit(p.Limit),
+		}
+	}
+
+	return []qm.QueryMod{
+		qm.OrderBy(p.Order),
+		qm.Limit(p.Limit),
+		qm.Offset(p.Offset),
+	}
+}
+
+// setHeaders : Writes the X-Total-Count and Link headers for the page
+func (p page) setHeaders(c *gin.Context, total int64) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+
+	last := 0
+	if total > 0 {
+		last = int((total - 1) / int64(p.Limit) * int64(p.Limit))
+	}
+
+	links := []string{
+		p.link(c, 0, "first"),
+	}
+	if p.Offset > 0 {
+		prev := p.Offset - p.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, p.link(c, prev, "prev"))
+	}
+	if int64(p.Offset+p.Limit) < total {
+		links = append(links, p.link(c, p.Offset+p.Limit, "next"))
+	}
+	links = append(links, p.link(c, last, "last"))
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// render : Writes a page of results. Keyset pages are wrapped with the
+// next_cursor to continue from, which is empty once the last page is reached
+func (p page) render(c *gin.Context, total int64, data interface{}, count int, lastID int) {
+	if !p.Cursor {
+		p.setHeaders(c, total)
+		c.JSON(200, data)
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+
+	next := ""
+	if count == p.Limit {
+		next = encodeCursor(p.resource, lastID)
+
+		u := *c.Request.URL
+		q := u.Query()
+		q.Set("limit", strconv.Itoa(p.Limit))
+		q.Set("cursor", next)
+		u.RawQuery = q.Encode()
+		c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", u.RequestURI()))
+	}
+
+	c.JSON(200, gin.H{"data": data, "next_cursor": next})
+}
+
+func (p page) link(c *gin.Context, offset int, rel string) string {
+	u := *c.Request.URL
+	q := u.Query()
+	q.Set("limit", strconv.Itoa(p.Limit))
+	q.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = q.Encode()
+
+	return fmt.Sprintf("<%s>; rel=\"%s\"", u.RequestURI(), rel)
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// includeDeleted : Parses the include_deleted query param, writing a 400 and
+// returning false when it isn't a boolean
+func includeDeleted(c *gin.Context, log log15.Logger) (bool, bool) {
+	return queryBool(c, log, "include_deleted")
+}
+
+// queryBool : Parses the boolean query param key, false when it's missing.
+// Writes a 400 and returns false when it isn't a boolean
+func queryBool(c *gin.Context, log log15.Logger, key string) (bool, bool) {
+	param := c.Query(key)
+	if param == "" {
+		return false, true
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		log.Error("gin: invalid "+key, key, param)
+		c.Error(problem.New(400, key+" must be true or false"))
+		c.Abort()
+		return false, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,160 @@
+package routes
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// patchOp : A single RFC 6902 operation
+type patchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyPatch : Applies the patch in the request body to model and returns the
+// columns it changed, for use as an Update whitelist. Bodies sent as
+// application/json-patch+json are treated as RFC 6902 JSON Patch documents,
+// anything else as an RFC 7396 merge patch. Writes a 400 and returns false
+// for malformed patches, or a 409 when a JSON Patch test op fails
+func applyPatch(c *gin.Context, log log15.Logger, model interface{}, columns []string) ([]string, bool) {
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, patchError(c, log, 400, "Request JSON isn't valid")
+	}
+
+	// Columns are all scalars, so patches are applied to the model's flat
+	// JSON representation and then bound back onto it
+	current, err := json.Marshal(model)
+	if err != nil {
+		return nil, patchError(c, log, 500, "Failed to apply patch")
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return nil, patchError(c, log, 500, "Failed to apply patch")
+	}
+
+	var cols []string
+	var ok bool
+	if c.ContentType() == "application/json-patch+json" {
+		cols, ok = jsonPatch(c, log, doc, body, columns)
+	} else {
+		cols, ok = mergePatch(c, log, doc, body, columns)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	merged, err := json.Marshal(doc)
+	if err != nil {
+		return nil, patchError(c, log, 500, "Failed to apply patch")
+	}
+	if err := json.Unmarshal(merged, model); err != nil {
+		return nil, patchError(c, log, 400, "Patch values don't match the field types")
+	}
+
+	return cols, true
+}
+
+// mergePatch : Overwrites each member of the RFC 7396 patch in body onto doc
+func mergePatch(c *gin.Context, log log15.Logger, doc map[string]json.RawMessage, body []byte, columns []string) ([]string, bool) {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, patchError(c, log, 400, "Request JSON isn't valid")
+	}
+
+	cols := make([]string, 0, len(patch))
+	for key, value := range patch {
+		if message := patchField(key, value, columns); message != "" {
+			return nil, patchError(c, log, 400, message)
+		}
+		doc[key] = value
+		cols = append(cols, key)
+	}
+	sort.Strings(cols)
+
+	return cols, true
+}
+
+// jsonPatch : Applies each operation of the RFC 6902 patch in body to doc in
+// order. Only add, replace and test are supported as columns can't be removed
+func jsonPatch(c *gin.Context, log log15.Logger, doc map[string]json.RawMessage, body []byte, columns []string) ([]string, bool) {
+	var ops []patchOp
+	if err := json.Unmarshal(body, &ops); err != nil {
+		return nil, patchError(c, log, 400, "Request JSON isn't valid")
+	}
+
+	var cols []string
+	for i, op := range ops {
+		if !strings.HasPrefix(op.Path, "/") || strings.Count(op.Path, "/") != 1 {
+			return nil, patchError(c, log, 400, "Invalid patch path: "+op.Path)
+		}
+		key := strings.NewReplacer("~1", "/", "~0", "~").Replace(op.Path[1:])
+
+		if op.Value == nil {
+			return nil, patchError(c, log, 400, "Patch op "+strconv.Itoa(i)+" is missing a value")
+		}
+
+		switch op.Op {
+		case "add", "replace":
+			if message := patchField(key, op.Value, columns); message != "" {
+				return nil, patchError(c, log, 400, message)
+			}
+			doc[key] = op.Value
+			if !contains(cols, key) {
+				cols = append(cols, key)
+			}
+		case "test":
+			if !contains(columns, key) {
+				return nil, patchError(c, log, 400, "unknown field: "+key)
+			}
+			if !jsonEqual(doc[key], op.Value) {
+				return nil, patchError(c, log, 409, "Patch test failed: "+op.Path)
+			}
+		default:
+			return nil, patchError(c, log, 400, "Unsupported patch op: "+op.Op)
+		}
+	}
+	sort.Strings(cols)
+
+	return cols, true
+}
+
+// patchField : Describes why key can't be patched to value, or returns ""
+func patchField(key string, value json.RawMessage, columns []string) string {
+	switch {
+	case !contains(columns, key):
+		return "unknown field: " + key
+	case key == "id" || key == "deleted_at":
+		return key + " can't be changed"
+	case string(value) == "null":
+		return key + " can't be null"
+	}
+
+	return ""
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+func patchError(c *gin.Context, log log15.Logger, code int, message string) bool {
+	log.Error("gin: invalid patch", "err", message)
+	c.Error(problem.New(code, message))
+	c.Abort()
+	return false
+}
@@ -3,9 +3,13 @@ package routes
 import (
 	"database/sql"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/phazyy/golang-rest-api/models"
+	"github.com/phazyy/golang-rest-api/problem"
+	"github.com/pkg/errors"
+	"github.com/vattle/sqlboiler/queries/qm"
 	"gopkg.in/inconshreveable/log15.v2"
 )
 
@@ -13,42 +17,137 @@ import (
 type PilotRoutes struct{}
 
 // Get : Attempts to fetch a single pilot matching passed ID
+// Related jets are embedded when passed ?include=jets
+// Only the columns named by ?fields= are returned when passed
+// Soft deleted pilots are only found when passed ?include_deleted=true
+// Responds with a 304 when If-None-Match matches the pilot's ETag
 func (route PilotRoutes) Get(c *gin.Context) {
 	db := c.MustGet("DB").(*sql.DB)
 	log := c.MustGet("logger").(log15.Logger)
 
-	paramID := c.Param("id")
-	id, _ := strconv.Atoi(paramID)
+	include, ok := includes(c, log, "jets")
+	if !ok {
+		return
+	}
 
-	pilot, err := models.FindPilot(db, id)
-	if err != nil {
-		log.Error("db: failed to get pilot", "id", id)
-		c.JSON(404, gin.H{"status": "404", "message": "Pilot not found"})
-		c.Abort()
+	fields, ok := sparseFields(c, log, models.PilotColumns())
+	if !ok {
+		return
+	}
+
+	withDeleted, ok := includeDeleted(c, log)
+	if !ok {
+		return
+	}
+
+	id := c.GetInt("id")
+
+	var pilot *models.Pilot
+	var err error
+	if withDeleted {
+		pilot, err = models.Pilots(db, qm.Select(fields.selectCols("id")...), qm.Where("id = ?", id)).One()
 	} else {
-		log.Info("db: fetched pilot", "id", id)
-		c.JSON(200, pilot)
+		pilot, err = models.FindActivePilot(db, id, fields.selectCols("id")...)
 	}
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	if include["jets"] {
+		if err := pilot.L.LoadJets(db, true, pilot); err != nil {
+			log.Error("db: failed to load pilot jets", "id", id, "err", err)
+			c.Error(problem.Wrap(err, 500, "Failed to fetch Jets"))
+			c.Abort()
+			return
+		}
+	}
+
+	resp := fields.project(withPilotRelations(pilot, include))
+	if notModified(c, resp, len(fields.fields) > 0 || len(include) > 0) {
+		log.Info("db: pilot not modified", "id", id)
+		return
+	}
+
+	log.Info("db: fetched pilot", "id", id)
+	c.JSON(200, resp)
 }
 
 // GetAll : Get all pilots
+// Related jets are embedded when passed ?include=jets
+// Results are narrowed by filter[column][op]=value params
+// Only the columns named by ?fields= are returned when passed
+// Soft deleted pilots are only listed when passed ?include_deleted=true
 func (route PilotRoutes) GetAll(c *gin.Context) {
 	db := c.MustGet("DB").(*sql.DB)
 	log := c.MustGet("logger").(log15.Logger)
 
-	pilots, err := models.Pilots(db).All()
+	include, ok := includes(c, log, "jets")
+	if !ok {
+		return
+	}
+
+	fields, ok := sparseFields(c, log, models.PilotColumns())
+	if !ok {
+		return
+	}
+
+	pg, ok := paginateKeyset(c, log, "pilots", models.PilotColumns())
+	if !ok {
+		return
+	}
+
+	where, ok := filters(c, log, models.Pilot{}, models.PilotColumns())
+	if !ok {
+		return
+	}
+
+	withDeleted, ok := includeDeleted(c, log)
+	if !ok {
+		return
+	}
+
+	query := models.ActivePilots
+	if withDeleted {
+		query = models.Pilots
+	}
+
+	total, err := query(db, where...).Count()
+	if err != nil {
+		log.Error("db: failed to count pilots", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Pilots"))
+		c.Abort()
+		return
+	}
+
+	mods := append(pg.mods(), where...)
+	if cols := fields.selectCols("id"); cols != nil {
+		mods = append(mods, qm.Select(cols...))
+	}
+	if include["jets"] {
+		mods = append(mods, qm.Load("Jets"))
+	}
+
+	pilots, err := query(db, mods...).All()
 	if err != nil {
 		log.Error("db: failed to get pilots", "err", err)
-		c.JSON(500, gin.H{"status": "500", "message": "Failed to fetch Pilots"})
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Pilots"))
 		c.Abort()
-	} else {
-		log.Info("db: fetched pilots", "count", len(pilots))
-		c.JSON(200, pilots)
+		return
+	}
+
+	lastID := 0
+	resp := make([]interface{}, len(pilots))
+	for i, pilot := range pilots {
+		resp[i] = fields.project(withPilotRelations(pilot, include))
+		lastID = pilot.ID
 	}
+
+	log.Info("db: fetched pilots", "count", len(pilots), "total", total)
+	pg.render(c, total, resp, len(pilots), lastID)
 }
 
 // Create : Create pilot with the passed name string
-// TODO : Add validation to json req
 func (route PilotRoutes) Create(c *gin.Context) {
 	db := c.MustGet("DB").(*sql.DB)
 	log := c.MustGet("logger").(log15.Logger)
@@ -56,12 +155,15 @@ func (route PilotRoutes) Create(c *gin.Context) {
 	var pilot models.Pilot
 	if c.BindJSON(&pilot) != nil {
 		log.Error("gin: error creating pilot")
-		c.JSON(400, gin.H{"status": "400", "message": "Request JSON isn't valid"})
+		c.Error(problem.New(400, "Request JSON isn't valid"))
 		c.Abort()
-	} else {
+	} else if validate(c, log, &pilot, pilotRules) {
+		// The database assigns ids, one in the body is ignored
+		pilot.ID = 0
+		pilot.DeletedAt.Valid = false
 		if err := pilot.Insert(db); err != nil {
 			log.Error("db: failed to insert pilot", "err", err)
-			c.JSON(500, gin.H{"status": "500", "message": "Failed to insert Pilot"})
+			c.Error(problem.Wrap(err, 500, "Failed to insert Pilot"))
 			c.Abort()
 		} else {
 			log.Info("db: insterted pilot", "id", pilot.ID)
@@ -70,43 +172,582 @@ func (route PilotRoutes) Create(c *gin.Context) {
 	}
 }
 
-// Update : Attempts to update the pilot matching the passed id
+// Update : Attempts to update the pilot matching the passed id. The pilot is
+// locked while If-Match is checked against its ETag, so concurrent writers
+// get a 412 rather than silently overwriting each other
 func (route PilotRoutes) Update(c *gin.Context) {
 	db := c.MustGet("DB").(*sql.DB)
 	log := c.MustGet("logger").(log15.Logger)
 
-	id, _ := strconv.Atoi(c.Param("id"))
+	id := c.GetInt("id")
 	var json models.Pilot
 	if c.BindJSON(&json) != nil {
-		log.Error("gin: error creating pilot")
+		log.Error("gin: error updating pilot", "id", id)
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	if !validate(c, log, &json, pilotRules) {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Pilot"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	pilot, err := models.ActivePilots(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Pilot", pilot) {
+		return
 	}
 
-	pilot, _ := models.FindPilot(db, id)
 	pilot.Name = json.Name
-	if err := pilot.Update(db); err != nil {
+	if err = pilot.Update(tx); err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
 		log.Error("db: failed to update pilot", "id", id, "err", err)
-		c.JSON(500, gin.H{"status": "500", "message": "Failed to update Pilot"})
+		c.Error(problem.Wrap(err, 500, "Failed to update Pilot"))
 		c.Abort()
 	} else {
 		log.Info("db: updated pilot", "id", id)
+		c.Header("ETag", etag(pilot))
 		c.JSON(200, gin.H{"message": "Pilot updated", "id": pilot.ID})
 	}
 }
 
-// Delete : Attempts to delete the pilot matching the passed id
+// Patch : Applies a JSON merge patch, or a JSON Patch when sent as
+// application/json-patch+json, to the pilot matching the passed id. The pilot is
+// locked for the duration so test ops and If-Match can't race other writers,
+// and only the columns the patch touches are updated
+func (route PilotRoutes) Patch(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Pilot"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	pilot, err := models.ActivePilots(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Pilot", pilot) {
+		return
+	}
+
+	cols, ok := applyPatch(c, log, pilot, models.PilotColumns())
+	if !ok || !validate(c, log, pilot, pilotRules, cols...) {
+		return
+	}
+
+	if len(cols) > 0 {
+		if err := pilot.Update(tx, cols...); err != nil {
+			log.Error("db: failed to patch pilot", "id", id, "err", err)
+			c.Error(problem.Wrap(err, 500, "Failed to update Pilot"))
+			c.Abort()
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("db: failed to commit pilot patch", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to update Pilot"))
+		c.Abort()
+		return
+	}
+
+	log.Info("db: patched pilot", "id", id, "columns", cols)
+	c.Header("ETag", etag(pilot))
+	c.JSON(200, gin.H{"message": "Pilot updated", "id": pilot.ID})
+}
+
+// Delete : Attempts to soft delete the pilot matching the passed id, which
+// can be undone with Restore. Jets the pilot still owns are handled by
+// ?on_jets=restrict (the default, a 409 listing the jet ids), ?on_jets=cascade
+// (deleted along with the pilot) or ?reassign=<pilot id> (moved to that pilot)
+// A 412 is returned when If-Match doesn't match the pilot's ETag
 func (route PilotRoutes) Delete(c *gin.Context) {
 	db := c.MustGet("DB").(*sql.DB)
 	log := c.MustGet("logger").(log15.Logger)
 
-	id, _ := strconv.Atoi(c.Param("id"))
+	id := c.GetInt("id")
 
-	pilot, _ := models.FindPilot(db, id)
-	if err := pilot.Delete(db); err != nil {
-		log.Error("db: failed to delete pilot", "id", id)
-		c.JSON(500, gin.H{"status": "500", "message": "Failed to delete Pilot"})
+	policy, reassignID, ok := deletePolicy(c, log, id)
+	if !ok {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Error("db: failed to begin transaction", "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to delete Pilot"))
+		c.Abort()
+		return
+	}
+	defer tx.Rollback()
+
+	pilot, err := models.ActivePilots(tx, qm.Where("id = ?", id), qm.For("UPDATE")).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	if !ifMatch(c, log, "Pilot", pilot) {
+		return
+	}
+
+	now := time.Now()
+	switch policy {
+	case "restrict":
+		var count int64
+		count, err = pilot.ActiveJets(tx).Count()
+		if err == nil && count > 0 {
+			var jets models.JetSlice
+			jets, err = models.ActiveJets(tx, qm.Select("id"), qm.Where("pilot_id = ?", id), qm.OrderBy("id")).All()
+			if err != nil {
+				break
+			}
+
+			ids := make([]int, len(jets))
+			for i, jet := range jets {
+				ids[i] = jet.ID
+			}
+
+			log.Error("db: pilot still owns jets", "id", id, "jets", ids)
+			c.Error(problem.New(409, "Pilot still owns Jets").With("jet_ids", ids))
+			c.Abort()
+			return
+		}
+	case "cascade":
+		// Jets share the pilot's deleted_at so Restore can bring them back too
+		err = pilot.ActiveJets(tx).UpdateAll(models.M{"deleted_at": now})
+	case "reassign":
+		// Lock the target too, so it can't be deleted before the jets land on it
+		_, err = models.ActivePilots(tx, qm.Where("id = ?", reassignID), qm.For("UPDATE")).One()
+		if errors.Cause(err) == sql.ErrNoRows {
+			log.Error("db: pilot doesn't exist", "id", reassignID)
+			c.Error(problem.New(422, "Pilot doesn't exist"))
+			c.Abort()
+			return
+		}
+		if err != nil {
+			break
+		}
+
+		_, err = tx.Exec(`update jets set pilot_id = $1 where pilot_id = $2 and deleted_at is null`, reassignID, id)
+	}
+
+	if err == nil {
+		err = pilot.SoftDelete(tx, now)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	if err != nil {
+		log.Error("db: failed to delete pilot", "id", id, "on_jets", policy, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to delete Pilot"))
 		c.Abort()
 	} else {
-		log.Info("db: deleted pilot", "id", id)
+		log.Info("db: deleted pilot", "id", id, "on_jets", policy)
 		c.JSON(204, gin.H{"message": "Pilot deleted", "id": pilot.ID})
 	}
 }
+
+// Restore : Restores the soft deleted pilot matching the passed id, along
+// with any jets that were cascade deleted with them
+func (route PilotRoutes) Restore(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	pilot, err := models.Pilots(db, qm.Where("id = ?", id)).One()
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	if !pilot.DeletedAt.Valid {
+		log.Error("db: pilot isn't deleted", "id", id)
+		c.Error(problem.New(409, "Pilot isn't deleted"))
+		c.Abort()
+		return
+	}
+
+	tx, err := db.Begin()
+	if err == nil {
+		err = models.Jets(tx, qm.Where("pilot_id = ? and deleted_at = ?", id, pilot.DeletedAt.Time)).
+			UpdateAll(models.M{"deleted_at": nil})
+		if err == nil {
+			err = pilot.Restore(tx)
+		}
+		if err == nil {
+			err = tx.Commit()
+		} else {
+			tx.Rollback()
+		}
+	}
+
+	if err != nil {
+		log.Error("db: failed to restore pilot", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to restore Pilot"))
+		c.Abort()
+	} else {
+		log.Info("db: restored pilot", "id", id)
+		c.JSON(200, gin.H{"message": "Pilot restored", "id": pilot.ID})
+	}
+}
+
+// pilotBatch : Body of a pilot batch, every list is optional
+type pilotBatch struct {
+	Create []models.Pilot `json:"create"`
+	Update []models.Pilot `json:"update"`
+	Delete []int          `json:"delete"`
+}
+
+// Batch : Creates, updates and soft deletes many pilots in one request,
+// responding with a result per item in the order they were sent. With
+// ?atomic=true everything runs in one transaction and the first failure rolls
+// it all back, otherwise each item succeeds or fails on its own. Pilots that
+// still own jets aren't deleted, as with ?on_jets=restrict
+func (route PilotRoutes) Batch(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	if !customMethod(c, log, "batch") {
+		return
+	}
+
+	var body pilotBatch
+	if c.BindJSON(&body) != nil {
+		log.Error("gin: error running pilot batch")
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	b, ok := beginBatch(c, db, log, len(body.Create)+len(body.Update)+len(body.Delete))
+	if !ok {
+		return
+	}
+
+	created := b.run(log, "create", 201, len(body.Create), func(i int) (int, error) {
+		pilot := body.Create[i]
+		pilot.ID = 0
+		pilot.DeletedAt.Valid = false
+		if errs := pilotRules.check(&pilot); len(errs) > 0 {
+			return 0, problem.Invalid(errs)
+		}
+
+		if err := pilot.Insert(b.exec); err != nil {
+			return 0, problem.Wrap(err, 500, "Failed to insert Pilot")
+		}
+		return pilot.ID, nil
+	})
+
+	updated := b.run(log, "update", 200, len(body.Update), func(i int) (int, error) {
+		json := body.Update[i]
+		if errs := pilotRules.check(&json); len(errs) > 0 {
+			return json.ID, problem.Invalid(errs)
+		}
+
+		pilot, err := models.FindActivePilot(b.exec, json.ID)
+		if err != nil {
+			return json.ID, lookupError(err, "Pilot")
+		}
+
+		pilot.Name = json.Name
+		if err := pilot.Update(b.exec); err != nil {
+			return pilot.ID, problem.Wrap(err, 500, "Failed to update Pilot")
+		}
+		return pilot.ID, nil
+	})
+
+	var pilots models.PilotSlice
+	deleted := b.run(log, "delete", 204, len(body.Delete), func(i int) (int, error) {
+		id := body.Delete[i]
+		pilot, err := models.FindActivePilot(b.exec, id)
+		if err != nil {
+			return id, lookupError(err, "Pilot")
+		}
+
+		jets, err := pilot.ActiveJets(b.exec).Count()
+		if err != nil {
+			return id, problem.Wrap(err, 500, "Failed to delete Pilot")
+		}
+		if jets > 0 {
+			return id, problem.New(409, "Pilot still owns Jets")
+		}
+
+		pilots = append(pilots, pilot)
+		return id, nil
+	})
+
+	// The checked pilots are soft deleted together
+	if len(pilots) > 0 && b.err == nil {
+		if err := pilots.UpdateAll(b.exec, models.M{"deleted_at": time.Now()}); err != nil {
+			b.fail(log, "delete", deleted, 204, problem.Wrap(err, 500, "Failed to delete Pilots"))
+		}
+	}
+
+	b.finish(c, log, gin.H{"create": created, "update": updated, "delete": deleted})
+}
+
+// GetJets : Get all jets owned by the pilot matching the passed id
+func (route PilotRoutes) GetJets(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	pg, ok := paginate(c, log, models.JetColumns())
+	if !ok {
+		return
+	}
+
+	id := c.GetInt("id")
+
+	pilot, err := models.FindActivePilot(db, id)
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	total, err := pilot.ActiveJets(db).Count()
+	if err != nil {
+		log.Error("db: failed to count pilot jets", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Jets"))
+		c.Abort()
+		return
+	}
+
+	jets, err := pilot.ActiveJets(db, pg.mods()...).All()
+	if err != nil {
+		log.Error("db: failed to get pilot jets", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Jets"))
+		c.Abort()
+	} else {
+		log.Info("db: fetched pilot jets", "id", id, "count", len(jets), "total", total)
+		pg.setHeaders(c, total)
+		c.JSON(200, jets)
+	}
+}
+
+// CreateJet : Create a jet and attach it to the pilot matching the passed id
+func (route PilotRoutes) CreateJet(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	id := c.GetInt("id")
+
+	pilot, err := models.FindActivePilot(db, id)
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	var jet models.Jet
+	if c.BindJSON(&jet) != nil {
+		log.Error("gin: error creating pilot jet", "id", id)
+		c.Error(problem.New(400, "Request JSON isn't valid"))
+		c.Abort()
+		return
+	}
+
+	jet.ID = 0
+	jet.PilotID = pilot.ID
+	jet.DeletedAt.Valid = false
+	if !validate(c, log, &jet, jetRules) {
+		return
+	}
+
+	if err := pilot.AddJets(db, true, &jet); err != nil {
+		log.Error("db: failed to insert pilot jet", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to insert Jet"))
+		c.Abort()
+	} else {
+		log.Info("db: inserted pilot jet", "id", id, "jet", jet.ID)
+		c.JSON(201, gin.H{"message": "Jet created", "id": jet.ID, "pilot_id": pilot.ID})
+	}
+}
+
+// GetLanguages : Get all languages spoken by the pilot matching the passed id
+func (route PilotRoutes) GetLanguages(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	pg, ok := paginate(c, log, models.LanguageColumns())
+	if !ok {
+		return
+	}
+
+	id := c.GetInt("id")
+
+	pilot, err := models.FindActivePilot(db, id)
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return
+	}
+
+	total, err := pilot.Languages(db).Count()
+	if err != nil {
+		log.Error("db: failed to count pilot languages", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Languages"))
+		c.Abort()
+		return
+	}
+
+	languages, err := pilot.Languages(db, pg.mods()...).All()
+	if err != nil {
+		log.Error("db: failed to get pilot languages", "id", id, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to fetch Languages"))
+		c.Abort()
+	} else {
+		log.Info("db: fetched pilot languages", "id", id, "count", len(languages), "total", total)
+		pg.setHeaders(c, total)
+		c.JSON(200, languages)
+	}
+}
+
+// AddLanguage : Attaches the language matching language_id to the pilot matching id
+func (route PilotRoutes) AddLanguage(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	pilot, language, ok := findPilotLanguage(c, db, log)
+	if !ok {
+		return
+	}
+
+	spoken, err := pilot.Languages(db, qm.Where("\"a\".\"id\"=?", language.ID)).Exists()
+	if err != nil {
+		log.Error("db: failed to check pilot language", "id", pilot.ID, "language", language.ID, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to attach Language"))
+		c.Abort()
+		return
+	}
+	if spoken {
+		log.Error("db: pilot language already attached", "id", pilot.ID, "language", language.ID)
+		c.Error(problem.New(409, "Pilot already speaks Language"))
+		c.Abort()
+		return
+	}
+
+	if err := pilot.AddLanguages(db, false, language); err != nil {
+		log.Error("db: failed to attach pilot language", "id", pilot.ID, "language", language.ID, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to attach Language"))
+		c.Abort()
+	} else {
+		log.Info("db: attached pilot language", "id", pilot.ID, "language", language.ID)
+		c.JSON(200, gin.H{"message": "Language attached", "id": pilot.ID, "language_id": language.ID})
+	}
+}
+
+// RemoveLanguage : Detaches the language matching language_id from the pilot matching id
+func (route PilotRoutes) RemoveLanguage(c *gin.Context) {
+	db := c.MustGet("DB").(*sql.DB)
+	log := c.MustGet("logger").(log15.Logger)
+
+	pilot, language, ok := findPilotLanguage(c, db, log)
+	if !ok {
+		return
+	}
+
+	spoken, err := pilot.Languages(db, qm.Where("\"a\".\"id\"=?", language.ID)).Exists()
+	if err != nil {
+		log.Error("db: failed to check pilot language", "id", pilot.ID, "language", language.ID, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to detach Language"))
+		c.Abort()
+		return
+	}
+	if !spoken {
+		log.Error("db: pilot language not attached", "id", pilot.ID, "language", language.ID)
+		c.Error(problem.New(404, "Pilot doesn't speak Language"))
+		c.Abort()
+		return
+	}
+
+	if err := pilot.RemoveLanguages(db, language); err != nil {
+		log.Error("db: failed to detach pilot language", "id", pilot.ID, "language", language.ID, "err", err)
+		c.Error(problem.Wrap(err, 500, "Failed to detach Language"))
+		c.Abort()
+	} else {
+		log.Info("db: detached pilot language", "id", pilot.ID, "language", language.ID)
+		c.JSON(204, gin.H{"message": "Language detached", "id": pilot.ID, "language_id": language.ID})
+	}
+}
+
+// deletePolicy : Parses the on_jets and reassign params of a pilot delete,
+// writing a 400 and returning false when they're unknown or conflicting
+func deletePolicy(c *gin.Context, log log15.Logger, id int) (string, int, bool) {
+	policy := c.DefaultQuery("on_jets", "restrict")
+
+	param := c.Query("reassign")
+	if param == "" {
+		if policy != "restrict" && policy != "cascade" {
+			log.Error("gin: invalid on_jets", "on_jets", policy)
+			c.Error(problem.New(400, "on_jets must be restrict or cascade"))
+			c.Abort()
+			return "", 0, false
+		}
+		return policy, 0, true
+	}
+
+	if c.Query("on_jets") != "" {
+		log.Error("gin: on_jets passed with reassign")
+		c.Error(problem.New(400, "on_jets can't be combined with reassign"))
+		c.Abort()
+		return "", 0, false
+	}
+
+	reassignID, err := strconv.Atoi(param)
+	if err != nil || reassignID < 1 || reassignID == id {
+		log.Error("gin: invalid reassign", "reassign", param)
+		c.Error(problem.New(400, "reassign must be the id of another pilot"))
+		c.Abort()
+		return "", 0, false
+	}
+
+	return "reassign", reassignID, true
+}
+
+// findPilotLanguage : Fetches the pilot and language matching the id and
+// language_id params, writing a 404 and returning false if either is missing
+func findPilotLanguage(c *gin.Context, db *sql.DB, log log15.Logger) (*models.Pilot, *models.Language, bool) {
+	id := c.GetInt("id")
+	languageID := c.GetInt("language_id")
+
+	pilot, err := models.FindActivePilot(db, id)
+	if err != nil {
+		lookupFailed(c, log, err, "Pilot", id)
+		return nil, nil, false
+	}
+
+	language, err := models.FindLanguage(db, languageID)
+	if err != nil {
+		lookupFailed(c, log, err, "Language", languageID)
+		return nil, nil, false
+	}
+
+	return pilot, language, true
+}
@@ -8,26 +8,58 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 	"github.com/magiconair/properties/assert"
+	"github.com/phazyy/golang-rest-api/config"
 	"github.com/phazyy/golang-rest-api/middleware"
+	"github.com/phazyy/golang-rest-api/models"
 	"github.com/phazyy/golang-rest-api/routes"
-	"github.com/phazyy/sqlboiler-gin/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/inconshreveable/log15.v2"
 )
 
 var log = log15.New()
 var pilotID int
 
+// adminToken : Served by SetupRouter's admin routes in place of the config's
+const adminToken = "test-admin-token"
+
 // SetupRouter :
 func SetupRouter() *gin.Engine {
-	r := gin.Default()
-	r.Use(middleware.Logger(log))
-	r.Use(middleware.Database(log))
+	cfg, err := config.Load(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	db, err := middleware.OpenDatabase(cfg.Postgres)
+	if err != nil {
+		panic(err)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewDBStatsCollector(db, cfg.Postgres.DBName))
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(middleware.Metrics(reg))
+	r.Use(middleware.Logger(log, "/healthz", "/readyz", "/metrics"))
+	r.Use(middleware.Database(db))
+	r.Use(middleware.Idempotency(log, cfg.IdempotencyTTL))
+	r.Use(middleware.Errors(log))
+	r.Use(middleware.IDs(log))
 	gin.SetMode(gin.TestMode)
 
+	health := new(routes.HealthRoutes)
+
+	r.GET("/healthz", health.Live)
+	r.GET("/readyz", health.Ready)
+	r.GET("/metrics", gin.WrapH(promhttp.HandlerFor(reg, promhttp.HandlerOpts{})))
+
 	v1 := r.Group("/v1")
 	{
 		pilot := new(routes.PilotRoutes)
@@ -35,8 +67,43 @@ func SetupRouter() *gin.Engine {
 		v1.GET("/pilots", pilot.GetAll)
 		v1.GET("/pilots/:id", pilot.Get)
 		v1.POST("/pilots", pilot.Create)
+		v1.POST("/pilots:batch", pilot.Batch)
 		v1.PUT("/pilots/:id", pilot.Update)
+		v1.PATCH("/pilots/:id", pilot.Patch)
 		v1.DELETE("/pilots/:id", pilot.Delete)
+		v1.POST("/pilots/:id/restore", pilot.Restore)
+		v1.GET("/pilots/:id/jets", pilot.GetJets)
+		v1.POST("/pilots/:id/jets", pilot.CreateJet)
+		v1.GET("/pilots/:id/languages", pilot.GetLanguages)
+		v1.PUT("/pilots/:id/languages/:language_id", pilot.AddLanguage)
+		v1.DELETE("/pilots/:id/languages/:language_id", pilot.RemoveLanguage)
+
+		jet := new(routes.JetRoutes)
+
+		v1.GET("/jets", jet.GetAll)
+		v1.GET("/jets/:id", jet.Get)
+		v1.POST("/jets", jet.Create)
+		v1.POST("/jets:batch", jet.Batch)
+		v1.PUT("/jets/:id", jet.Update)
+		v1.PATCH("/jets/:id", jet.Patch)
+		v1.DELETE("/jets/:id", jet.Delete)
+		v1.POST("/jets/:id/restore", jet.Restore)
+		v1.PUT("/jets/:id/pilot", jet.SetPilot)
+
+		language := new(routes.LanguageRoutes)
+
+		v1.GET("/languages", language.GetAll)
+		v1.GET("/languages/:id", language.Get)
+		v1.POST("/languages", language.Create)
+		v1.PUT("/languages/:id", language.Update)
+		v1.DELETE("/languages/:id", language.Delete)
+	}
+
+	admin := new(routes.AdminRoutes)
+	group := v1.Group("/admin", middleware.Admin(log, adminToken))
+	{
+		group.DELETE("/pilots/:id", admin.PurgePilot)
+		group.DELETE("/jets/:id", admin.PurgeJet)
 	}
 	return r
 }
@@ -76,6 +143,72 @@ func TestCreatePilot(t *testing.T) {
 	assert.Equal(t, res.Code, 201)
 }
 
+// TestCreatePilotIdempotent : Assert pilot creation retried with an Idempotency-Key - must replay the first 201
+func TestCreatePilotIdempotent(t *testing.T) {
+	testRouter := SetupRouter()
+	key := fmt.Sprintf("pilot-%d", time.Now().UnixNano())
+
+	ids := make([]int, 2)
+	for i := range ids {
+		req, err := http.NewRequest("POST", "/v1/pilots", bytes.NewBufferString(`{"name": "Goose"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+
+		resp := struct {
+			ID int `json:"id"`
+		}{}
+		json.Unmarshal(res.Body.Bytes(), &resp)
+		ids[i] = resp.ID
+
+		assert.Equal(t, res.Code, 201)
+		assert.Equal(t, res.Header().Get("Idempotent-Replayed") == "true", i > 0)
+	}
+	assert.Equal(t, ids[1], ids[0])
+
+	for _, test := range []struct{ url, body string }{
+		{"/v1/pilots", `{"name": "Maverick"}`},
+		{"/v1/pilots?dry_run=true", `{"name": "Goose"}`},
+	} {
+		req, err := http.NewRequest("POST", test.url, bytes.NewBufferString(test.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, 422, test.url+" "+test.body)
+	}
+}
+
+// TestIdempotentPanic : Assert a panicking request releases its Idempotency-Key - must return 500 on every retry
+func TestIdempotentPanic(t *testing.T) {
+	testRouter := SetupRouter()
+	testRouter.POST("/v1/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	key := fmt.Sprintf("panic-%d", time.Now().UnixNano())
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("POST", "/v1/panic", bytes.NewBufferString("{}"))
+		req.Header.Set("Idempotency-Key", key)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, 500)
+	}
+}
+
 // TestCreateInvalidPilot : Assert invalid pilot create - must return 400
 func TestCreateInvalidPilot(t *testing.T) {
 	testRouter := SetupRouter()
@@ -92,6 +225,102 @@ func TestCreateInvalidPilot(t *testing.T) {
 	assert.Equal(t, res.Code, 400)
 }
 
+// TestCreateBlankPilot : Assert pilot create without a name - must return 422
+func TestCreateBlankPilot(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("POST", "/v1/pilots", bytes.NewBufferString(`{"name": ""}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 422)
+}
+
+// TestBatchPilots : Assert best effort pilot batch - must return 200 with a result per item
+func TestBatchPilots(t *testing.T) {
+	testRouter := SetupRouter()
+
+	body := fmt.Sprintf(`{"create": [{"id": %d, "name": "Hollywood"}, {"name": ""}], "delete": [%d]}`, pilotID, missingID)
+	req, err := http.NewRequest("POST", "/v1/pilots:batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := map[string][]struct {
+		Status int `json:"status"`
+		ID     int `json:"id"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp["create"]), 2)
+	assert.Equal(t, resp["create"][0].Status, 201)
+	assert.Equal(t, resp["create"][0].ID > 0, true)
+	assert.Equal(t, resp["create"][0].ID != pilotID, true)
+	assert.Equal(t, resp["create"][1].Status, 422)
+	assert.Equal(t, resp["create"][1].ID, 0)
+	assert.Equal(t, len(resp["delete"]), 1)
+	assert.Equal(t, resp["delete"][0].Status, 404)
+}
+
+// TestBatchPilotsAtomic : Assert atomic pilot batch with an invalid item - must return 422 naming the item
+func TestBatchPilotsAtomic(t *testing.T) {
+	testRouter := SetupRouter()
+
+	body := `{"create": [{"name": "Wolfman"}, {"name": ""}]}`
+	req, err := http.NewRequest("POST", "/v1/pilots:batch?atomic=true", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		Operation string `json:"operation"`
+		Index     int    `json:"index"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 422)
+	assert.Equal(t, resp.Operation, "create")
+	assert.Equal(t, resp.Index, 1)
+}
+
+// TestBatchPilotsInvalid : Assert empty batches and unknown custom methods - must return 400 and 404
+func TestBatchPilotsInvalid(t *testing.T) {
+	testRouter := SetupRouter()
+
+	for _, test := range []struct {
+		url  string
+		code int
+	}{
+		{"/v1/pilots:batch", 400},
+		{"/v1/pilots:batch?atomic=maybe", 400},
+		{"/v1/pilots:merge", 404},
+	} {
+		req, err := http.NewRequest("POST", test.url, bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, test.code, test.url)
+	}
+}
+
 // TestGetPilot : Assert pilot fetch - must return 200
 func TestGetPilot(t *testing.T) {
 	testRouter := SetupRouter()
@@ -122,6 +351,95 @@ func TestGetPilot(t *testing.T) {
 	assert.Equal(t, resp.Name, "Adam")
 }
 
+// TestGetPilotsPage : Assert paginated pilot fetch - must return 200
+func TestGetPilotsPage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/pilots?limit=1&offset=0", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	var resp []models.Pilot
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp), 1)
+	assert.Equal(t, res.Header().Get("X-Total-Count") != "", true)
+	assert.Equal(t, res.Header().Get("Link") != "", true)
+}
+
+// TestGetPilotsInvalidPage : Assert invalid pagination params - must return 400
+func TestGetPilotsInvalidPage(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/pilots?limit=-1", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestGetPilotsCursor : Assert keyset pilot fetch follows next_cursor - must return 200
+func TestGetPilotsCursor(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/pilots?cursor=&limit=1", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		Data       []models.Pilot
+		NextCursor string `json:"next_cursor"`
+	}{}
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp.Data), 1)
+	assert.Equal(t, resp.NextCursor != "", true)
+
+	first := resp.Data[0].ID
+	req, err = http.NewRequest("GET", "/v1/pilots?limit=1&cursor="+resp.NextCursor, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, len(resp.Data) == 0 || resp.Data[0].ID > first, true)
+}
+
+// TestGetPilotsForgedCursor : Assert tampered cursor - must return 400
+func TestGetPilotsForgedCursor(t *testing.T) {
+	testRouter := SetupRouter()
+
+	req, err := http.NewRequest("GET", "/v1/pilots?cursor=cGlsb3RzOjE.Zm9yZ2Vk", nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
 // TestGetInvalidPilot : Assert negative pilot fetch - must return 404
 func TestGetInvalidPilot(t *testing.T) {
 	testRouter := SetupRouter()
@@ -138,6 +456,33 @@ func TestGetInvalidPilot(t *testing.T) {
 	assert.Equal(t, res.Code, 404)
 }
 
+// TestGetInvalidPilotProblem : Assert errors are rendered as problem+json - must return 404
+func TestGetInvalidPilotProblem(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d", pilotID+1)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		Title    string
+		Status   int
+		Instance string
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 404)
+	assert.Equal(t, res.Header().Get("Content-Type"), "application/problem+json")
+	assert.Equal(t, resp.Status, 404)
+	assert.Equal(t, resp.Title, "Not Found")
+	assert.Equal(t, resp.Instance, url)
+}
+
 // TestUpdatePilot : Assert pilot update - must return 200
 func TestUpdatePilot(t *testing.T) {
 	testRouter := SetupRouter()
@@ -158,6 +503,83 @@ func TestUpdatePilot(t *testing.T) {
 	assert.Equal(t, res.Code, 200)
 }
 
+// TestGetPilotNotModified : Assert pilot fetch with a matching If-None-Match - must return 304
+func TestGetPilotNotModified(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d", pilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	tag := res.Header().Get("ETag")
+	assert.Equal(t, res.Code, 200)
+	assert.Equal(t, tag != "", true)
+
+	req.Header.Set("If-None-Match", tag)
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	assert.Equal(t, res.Code, 304)
+	assert.Equal(t, res.Body.Len(), 0)
+}
+
+// TestUpdatePilotIfMatch : Assert pilot update guarded by If-Match - must return 412 when stale, then 200
+func TestUpdatePilotIfMatch(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d", pilotID)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	tag := res.Header().Get("ETag")
+
+	for _, test := range []struct {
+		ifMatch string
+		code    int
+	}{
+		{`"stale"`, 412},
+		{tag, 200},
+		{tag, 412},
+	} {
+		req, err := http.NewRequest("PUT", url, bytes.NewBufferString(`{"name": "etagAdam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", test.ifMatch)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		res := httptest.NewRecorder()
+		testRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, test.code, test.ifMatch)
+	}
+}
+
+// TestDeletePilotIfMatch : Assert pilot deletion with a stale If-Match - must return 412
+func TestDeletePilotIfMatch(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d", pilotID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	req.Header.Set("If-Match", `"stale"`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 412)
+}
+
 // TestDeletePilot : Assert pilot deletion - must return 204
 func TestDeletePilot(t *testing.T) {
 	testRouter := SetupRouter()
@@ -173,3 +595,129 @@ func TestDeletePilot(t *testing.T) {
 	testRouter.ServeHTTP(res, req)
 	assert.Equal(t, res.Code, 204)
 }
+
+// TestDeletePilotRestrict : Assert deleting a pilot who owns jets - must return 409
+func TestDeletePilotRestrict(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d", jetPilotID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := struct {
+		JetIDs []int `json:"jet_ids"`
+	}{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, res.Code, 409)
+	assert.Equal(t, len(resp.JetIDs) > 0, true)
+}
+
+// TestDeletePilotInvalidPolicy : Assert deleting a pilot with an unknown policy - must return 400
+func TestDeletePilotInvalidPolicy(t *testing.T) {
+	testRouter := SetupRouter()
+
+	url := fmt.Sprintf("/v1/pilots/%d?on_jets=orphan", jetPilotID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 400)
+}
+
+// TestDeletePilotReassign : Assert deleting a pilot and reassigning their jets - must return 204
+func TestDeletePilotReassign(t *testing.T) {
+	testRouter := SetupRouter()
+	pilotID, jetID := createPilotJet(testRouter, "Goose")
+
+	url := fmt.Sprintf("/v1/pilots/%d?reassign=%d", pilotID, jetPilotID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 204)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/v1/jets/%d", jetID), nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+
+	resp := models.Jet{}
+	json.Unmarshal(res.Body.Bytes(), &resp)
+
+	assert.Equal(t, resp.PilotID, jetPilotID)
+}
+
+// TestDeletePilotReassignMissing : Assert deleting a pilot and reassigning to an unknown pilot - must return 422
+func TestDeletePilotReassignMissing(t *testing.T) {
+	testRouter := SetupRouter()
+	pilotID, _ := createPilotJet(testRouter, "Merlin")
+
+	url := fmt.Sprintf("/v1/pilots/%d?reassign=%d", pilotID, missingID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 422)
+}
+
+// TestDeletePilotCascade : Assert deleting and restoring a pilot along with their jets - must return 204
+func TestDeletePilotCascade(t *testing.T) {
+	testRouter := SetupRouter()
+	pilotID, jetID := createPilotJet(testRouter, "Ice")
+
+	url := fmt.Sprintf("/v1/pilots/%d?on_jets=cascade", pilotID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res := httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 204)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/v1/jets/%d", jetID), nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 404)
+
+	req, err = http.NewRequest("POST", fmt.Sprintf("/v1/pilots/%d/restore", pilotID), nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+
+	req, err = http.NewRequest("GET", fmt.Sprintf("/v1/jets/%d", jetID), nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	res = httptest.NewRecorder()
+	testRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, 200)
+}
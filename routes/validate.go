@@ -0,0 +1,124 @@
+package routes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phazyy/golang-rest-api/problem"
+	"gopkg.in/inconshreveable/log15.v2"
+)
+
+// rule : Describes why value is invalid, or returns "" when it's valid
+type rule func(value reflect.Value) string
+
+// ruleset : Rules checked against a model, keyed by column
+type ruleset map[string][]rule
+
+var pilotRules = ruleset{
+	"name": {required, maxLength(255)},
+}
+
+var jetRules = ruleset{
+	"pilot_id": {required},
+	"age":      {minimum(0)},
+	"name":     {required, maxLength(255)},
+	"color":    {required, maxLength(255)},
+}
+
+var languageRules = ruleset{
+	"language": {required, maxLength(255)},
+}
+
+// validate : Checks the boil tagged columns of model against rules, writing a
+// 422 listing every invalid field and returning false when any fail. Passing
+// cols limits the check to those columns, for partial updates
+func validate(c *gin.Context, log log15.Logger, model interface{}, rules ruleset, cols ...string) bool {
+	if errs := rules.check(model, cols...); len(errs) > 0 {
+		log.Error("gin: request failed validation", "errors", len(errs))
+		c.Error(problem.Invalid(errs))
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// check : Returns an error for every column of model that breaks rules,
+// limited to cols when passed
+func (rules ruleset) check(model interface{}, cols ...string) []problem.FieldError {
+	values := columnValues(model)
+
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		if len(cols) == 0 || contains(cols, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var errs []problem.FieldError
+	for _, key := range keys {
+		for _, check := range rules[key] {
+			if message := check(values[key]); message != "" {
+				errs = append(errs, problem.FieldError{Field: key, Message: message})
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// columnValues : Maps each boil tagged column of model to its value
+func columnValues(model interface{}) map[string]reflect.Value {
+	values := make(map[string]reflect.Value)
+
+	v := reflect.Indirect(reflect.ValueOf(model))
+	for i := 0; i < v.NumField(); i++ {
+		if tag := v.Type().Field(i).Tag.Get("boil"); tag != "" && tag != "-" {
+			values[tag] = v.Field(i)
+		}
+	}
+
+	return values
+}
+
+// required : Strings must contain more than whitespace, numbers must be non zero
+func required(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		if strings.TrimSpace(value.String()) == "" {
+			return "is required"
+		}
+	case reflect.Int, reflect.Int64:
+		if value.Int() == 0 {
+			return "is required"
+		}
+	}
+
+	return ""
+}
+
+// maxLength : Strings must be at most n characters
+func maxLength(n int) rule {
+	return func(value reflect.Value) string {
+		if value.Kind() == reflect.String && utf8.RuneCountInString(value.String()) > n {
+			return fmt.Sprintf("must be at most %d characters", n)
+		}
+		return ""
+	}
+}
+
+// minimum : Numbers must be at least n
+func minimum(n int64) rule {
+	return func(value reflect.Value) string {
+		if (value.Kind() == reflect.Int || value.Kind() == reflect.Int64) && value.Int() < n {
+			return fmt.Sprintf("must be at least %d", n)
+		}
+		return ""
+	}
+}